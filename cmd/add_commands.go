@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/erwinhermantodev/hexa-go/internal/config"
 	"github.com/erwinhermantodev/hexa-go/internal/generator"
+	"github.com/erwinhermantodev/hexa-go/internal/importer"
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+	"github.com/erwinhermantodev/hexa-go/internal/manifest"
 	"github.com/erwinhermantodev/hexa-go/internal/prompts"
+	"github.com/erwinhermantodev/hexa-go/internal/spec"
 	"github.com/erwinhermantodev/hexa-go/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +24,18 @@ var addModelCmd = &cobra.Command{
 	Run:   addModel,
 }
 
+var addFieldCmd = &cobra.Command{
+	Use:   "field [model-name] [field...]",
+	Short: "Add fields to an existing model and a migration adding their columns",
+	Long: `Add fields to an existing model, written like the --fields of add model
+(name:type:options:validation). A migration altering its table is written
+to migrations/ and the model files are regenerated. Files edited since they
+were generated are only replaced with --force or --ask, otherwise
+nothing is changed.`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  addField,
+}
+
 var addServiceCmd = &cobra.Command{
 	Use:   "service [service-name]",
 	Short: "Add a new service",
@@ -33,101 +51,323 @@ var addHandlerCmd = &cobra.Command{
 }
 
 func init() {
-	addModelCmd.Flags().StringSliceP("fields", "f", []string{}, "Model fields (format: name:type:tag:validation)")
+	addModelCmd.Flags().StringArrayP("fields", "f", []string{}, "Model fields, repeat for each field (format: name:type:options|tag:validation, options: unique,index,size=N,default=V,column=C,readonly,writeonly)")
+	addModelCmd.Flags().StringArrayP("relations", "r", []string{}, "Model relations (format: name:kind[:model|table], kind is belongs_to, has_one, has_many or many2many)")
+	addModelCmd.Flags().StringP("primary-key", "", "", "Primary key strategy: uint (default), int64, uuid, uuidv7, ulid or composite(FieldA,FieldB)")
+	addModelCmd.Flags().StringP("auth", "", "", "Route access: public (default), protected by the JWT middleware, or mixed (reads public, writes protected)")
 	addModelCmd.Flags().BoolP("no-repo", "", false, "Skip repository generation")
 	addModelCmd.Flags().BoolP("no-service", "", false, "Skip service generation")
 	addModelCmd.Flags().BoolP("no-handler", "", false, "Skip handler generation")
+	addModelCmd.Flags().StringP("from-json", "", "", "Infer the fields from a sample JSON payload")
+	addModelCmd.Flags().StringP("nested", "", "related", "How nested objects of --from-json are stored: related (belongs_to and has_many models) or embedded (embedded structs)")
+	addModelCmd.MarkFlagsMutuallyExclusive("fields", "from-json")
+
+	for _, cmd := range []*cobra.Command{addModelCmd, addFieldCmd, addServiceCmd, addHandlerCmd} {
+		addGeneratorFlags(cmd)
+	}
 }
 
 func addModel(cmd *cobra.Command, args []string) {
-	modelName := args[0]
-	fields, _ := cmd.Flags().GetStringSlice("fields")
+	modelName := inflect.Pascal(args[0])
+	fields, _ := cmd.Flags().GetStringArray("fields")
+	relationFlags, _ := cmd.Flags().GetStringArray("relations")
+	primaryKey, _ := cmd.Flags().GetString("primary-key")
+	auth, _ := cmd.Flags().GetString("auth")
 	noRepo, _ := cmd.Flags().GetBool("no-repo")
 	noService, _ := cmd.Flags().GetBool("no-service")
 	noHandler, _ := cmd.Flags().GetBool("no-handler")
+	fromJSON, _ := cmd.Flags().GetString("from-json")
+	nested, _ := cmd.Flags().GetString("nested")
 
 	if !utils.FileExists("go.mod") {
 		fmt.Println("❌ No go.mod found. Please run this command in a Go project directory.")
 		return
 	}
+	if nested != "related" && nested != "embedded" {
+		fmt.Printf("❌ Invalid --nested %q (use related or embedded)\n", nested)
+		return
+	}
+
+	projectConfig, err := loadProject()
+	if err != nil {
+		fmt.Printf("❌ Error loading %s: %v\n", manifest.FileName, err)
+		return
+	}
+	if _, exists := projectConfig.FindModel(modelName); exists {
+		fmt.Printf("❌ Model '%s' already exists in %s\n", modelName, manifest.FileName)
+		return
+	}
+
+	relations, err := utils.ParseRelationsFromFlags(relationFlags)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	modelFields, err := utils.ParseFieldsFromFlags(fields)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
 
 	modelConfig := config.ModelConfig{
 		Name:       modelName,
-		Fields:     utils.ParseFieldsFromFlags(fields),
+		Fields:     modelFields,
+		Relations:  relations,
+		PrimaryKey: primaryKey,
+		Auth:       auth,
 		HasRepo:    !noRepo,
 		HasService: !noService,
 		HasHandler: !noHandler,
 	}
 
+	// Models inferred from a sample come with the nested models they
+	// refer to, which are generated first
+	var nestedModels []config.ModelConfig
+	if fromJSON != "" {
+		inferred, err := sampleModels(projectConfig, modelConfig, fromJSON, nested == "embedded")
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		nestedModels = inferred[:len(inferred)-1]
+		modelConfig = inferred[len(inferred)-1]
+	}
+
 	if len(modelConfig.Fields) == 0 {
 		modelConfig.Fields = prompts.PromptForModelFields(modelName)
 	}
 
-	projectConfig := config.ProjectConfig{
-		ModuleName: utils.GetModuleName(),
-		Models:     []config.ModelConfig{modelConfig},
+	projectConfig.Models = append(projectConfig.Models, nestedModels...)
+	projectConfig.Models = append(projectConfig.Models, modelConfig)
+	if err := spec.Validate(manifest.FileName, &projectConfig); err != nil {
+		fmt.Printf("❌ Invalid model:\n%v\n", err)
+		return
 	}
+	nestedModels = projectConfig.Models[len(projectConfig.Models)-1-len(nestedModels) : len(projectConfig.Models)-1]
+	modelConfig = projectConfig.Models[len(projectConfig.Models)-1]
 
-	gen := generator.New()
-	if err := gen.GenerateModelFiles(projectConfig, modelConfig); err != nil {
+	opts := generatorOptions(cmd)
+	gen := generator.NewWithOptions(opts)
+	if err := gen.AddModels(projectConfig, append(nestedModels, modelConfig)); err != nil {
 		fmt.Printf("❌ Error generating model: %v\n", err)
 		return
 	}
+	if opts.DryRun {
+		return
+	}
 
 	fmt.Printf("✅ Model '%s' generated successfully!\n", modelName)
 	if modelConfig.HasRepo {
-		fmt.Printf("  📝 Generated repository: repository/%s.go\n", strings.ToLower(modelName))
+		fmt.Printf("  📝 Generated repository: repository/%s.go\n", inflect.Snake(modelName))
 	}
 	if modelConfig.HasService {
-		fmt.Printf("  🔧 Generated service: service/%s.go\n", strings.ToLower(modelName))
+		fmt.Printf("  🔧 Generated service: service/%s.go\n", inflect.Snake(modelName))
 	}
 	if modelConfig.HasHandler {
-		fmt.Printf("  🌐 Generated handler: transport/http/handler/%s_handler.go\n", strings.ToLower(modelName))
+		fmt.Printf("  🌐 Generated handler: transport/http/handler/%s_handler.go\n", inflect.Snake(modelName))
+	}
+	fmt.Printf("  📋 Generated model: model/%s.go\n", inflect.Snake(modelName))
+	for _, model := range nestedModels {
+		fmt.Printf("  📋 Generated nested model: model/%s.go\n", inflect.Snake(model.Name))
 	}
-	fmt.Printf("  📋 Generated model: model/%s.go\n", strings.ToLower(modelName))
+	fmt.Println("  🔌 Updated wiring: wire_gen.go")
+}
+
+// sampleModels infers the fields of model from a sample JSON file. The
+// flags given for the model, such as its layers and relations, are kept.
+// The returned models end with model, preceded by its nested models.
+func sampleModels(projectConfig config.ProjectConfig, model config.ModelConfig, path string, embedded bool) ([]config.ModelConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	result, err := importer.SampleModels(model.Name, data, importer.Options{
+		HasRepo:    model.HasRepo,
+		HasService: model.HasService,
+		HasHandler: model.HasHandler,
+		Embedded:   embedded,
+	}, projectConfig.ModelNames())
+	if err != nil {
+		return nil, fmt.Errorf("error inferring fields from %s: %w", path, err)
+	}
+	for _, warning := range result.Warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
+	models := result.Models
+	inferred := &models[len(models)-1]
+	inferred.Relations = append(inferred.Relations, model.Relations...)
+	if model.PrimaryKey != "" {
+		inferred.PrimaryKey = model.PrimaryKey
+	}
+	inferred.Auth = model.Auth
+	return models, nil
+}
+
+func addField(cmd *cobra.Command, args []string) {
+	modelName := inflect.Pascal(args[0])
+
+	if !utils.FileExists("go.mod") {
+		fmt.Println("❌ No go.mod found. Please run this command in a Go project directory.")
+		return
+	}
+
+	projectConfig, err := loadProject()
+	if err != nil {
+		fmt.Printf("❌ Error loading %s: %v\n", manifest.FileName, err)
+		return
+	}
+	fields, err := utils.ParseFieldsFromFlags(args[1:])
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	if len(fields) < len(args)-1 {
+		fmt.Println("❌ Fields must be written as name:type[:options[:validation]]")
+		return
+	}
+
+	var names []string
+	for _, field := range fields {
+		names = append(names, inflect.Snake(field.Name))
+	}
+	updateModel(cmd, projectConfig, modelName, "add_"+strings.Join(names, "_and_")+"_to_", func(model *config.ModelConfig) error {
+		model.Fields = append(model.Fields, fields...)
+		return nil
+	})
+}
+
+// updateModel applies change to a copy of the named model and regenerates
+// it with a migration named after the change and the model's table
+func updateModel(cmd *cobra.Command, projectConfig config.ProjectConfig, modelName, change string, apply func(model *config.ModelConfig) error) {
+	previous := projectConfig
+	projectConfig.Models = append([]config.ModelConfig(nil), previous.Models...)
+
+	index := -1
+	for i, model := range projectConfig.Models {
+		if model.Name == modelName {
+			index = i
+		}
+	}
+	if index < 0 {
+		fmt.Printf("❌ Model '%s' not found in %s\n", modelName, manifest.FileName)
+		return
+	}
+	model := &projectConfig.Models[index]
+	model.Fields = append([]config.FieldConfig(nil), model.Fields...)
+	if err := apply(model); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	if err := spec.Validate(manifest.FileName, &projectConfig); err != nil {
+		fmt.Printf("❌ Invalid model:\n%v\n", err)
+		return
+	}
+
+	opts := generatorOptions(cmd)
+	gen := generator.NewWithOptions(opts)
+	if err := gen.UpdateModel(previous, projectConfig, projectConfig.Models[index], change+projectConfig.Models[index].TableName()); err != nil {
+		fmt.Printf("❌ Error updating model: %v\n", err)
+		return
+	}
+	if opts.DryRun {
+		return
+	}
+
+	fmt.Printf("✅ Model '%s' updated successfully!\n", modelName)
+	fmt.Printf("  📋 Model: model/%s.go\n", inflect.Snake(modelName))
+	fmt.Printf("  🗄️  Migration: migrations/*_%s%s.{up,down}.sql\n", change, projectConfig.Models[index].TableName())
 }
 
 func addService(cmd *cobra.Command, args []string) {
-	serviceName := args[0]
+	serviceName := inflect.Pascal(args[0])
 
 	if !utils.FileExists("go.mod") {
 		fmt.Println("❌ No go.mod found. Please run this command in a Go project directory.")
 		return
 	}
 
-	projectConfig := config.ProjectConfig{
-		ModuleName: utils.GetModuleName(),
-		Services:   []string{serviceName},
+	projectConfig, err := loadProject()
+	if err != nil {
+		fmt.Printf("❌ Error loading %s: %v\n", manifest.FileName, err)
+		return
+	}
+	if projectConfig.HasService(serviceName) {
+		fmt.Printf("❌ Service '%s' already exists in %s\n", serviceName, manifest.FileName)
+		return
 	}
 
-	gen := generator.New()
-	if err := gen.GenerateServiceFile(projectConfig, serviceName); err != nil {
+	projectConfig.Services = append(projectConfig.Services, serviceName)
+
+	opts := generatorOptions(cmd)
+	gen := generator.NewWithOptions(opts)
+	if err := gen.AddService(projectConfig, serviceName); err != nil {
 		fmt.Printf("❌ Error generating service: %v\n", err)
 		return
 	}
+	if opts.DryRun {
+		return
+	}
 
 	fmt.Printf("✅ Service '%s' generated successfully!\n", serviceName)
-	fmt.Printf("  🔧 Generated: service/%s.go\n", strings.ToLower(serviceName))
+	fmt.Printf("  🔧 Generated: service/%s.go\n", inflect.Snake(serviceName))
+	fmt.Println("  🔌 Updated wiring: wire_gen.go")
 }
 
 func addHandler(cmd *cobra.Command, args []string) {
-	handlerName := args[0]
+	handlerName := inflect.Pascal(args[0])
 
 	if !utils.FileExists("go.mod") {
 		fmt.Println("❌ No go.mod found. Please run this command in a Go project directory.")
 		return
 	}
 
-	projectConfig := config.ProjectConfig{
-		ModuleName: utils.GetModuleName(),
+	projectConfig, err := loadProject()
+	if err != nil {
+		fmt.Printf("❌ Error loading %s: %v\n", manifest.FileName, err)
+		return
+	}
+	if projectConfig.HasHandler(handlerName) {
+		fmt.Printf("❌ Handler '%s' already exists in %s\n", handlerName, manifest.FileName)
+		return
 	}
 
-	gen := generator.New()
-	if err := gen.GenerateHandlerFile(projectConfig, handlerName); err != nil {
+	projectConfig.Handlers = append(projectConfig.Handlers, handlerName)
+
+	opts := generatorOptions(cmd)
+	gen := generator.NewWithOptions(opts)
+	if err := gen.AddHandler(projectConfig, handlerName); err != nil {
 		fmt.Printf("❌ Error generating handler: %v\n", err)
 		return
 	}
+	if opts.DryRun {
+		return
+	}
 
 	fmt.Printf("✅ Handler '%s' generated successfully!\n", handlerName)
-	fmt.Printf("  🌐 Generated: transport/http/handler/%s_handler.go\n", strings.ToLower(handlerName))
+	fmt.Printf("  🌐 Generated: transport/http/handler/%s_handler.go\n", inflect.Snake(handlerName))
+	fmt.Println("  🔌 Updated wiring: wire_gen.go")
+}
+
+// loadProject reads the manifest of the project in the current directory.
+// Projects generated before manifests existed get one reconstructed from
+// go.mod, which is written back after the first successful add.
+func loadProject() (config.ProjectConfig, error) {
+	if manifest.Exists(".") {
+		return manifest.Load(".")
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+
+	fmt.Printf("⚠️  No %s found, creating one from go.mod\n", manifest.FileName)
+	return config.ProjectConfig{
+		Name:       filepath.Base(wd),
+		ModuleName: utils.GetModuleName(),
+		Dir:        ".",
+	}, nil
 }
@@ -3,10 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/erwinhermantodev/hexa-go/internal/config"
 	"github.com/erwinhermantodev/hexa-go/internal/generator"
 	"github.com/erwinhermantodev/hexa-go/internal/prompts"
+	"github.com/erwinhermantodev/hexa-go/internal/spec"
 	"github.com/spf13/cobra"
 )
 
@@ -23,9 +25,23 @@ func init() {
 	generateCmd.Flags().StringP("description", "d", "", "Project description")
 	generateCmd.Flags().BoolP("interactive", "i", false, "Interactive mode for defining models")
 	generateCmd.Flags().BoolP("minimal", "", false, "Generate minimal project without auth")
+	generateCmd.Flags().StringP("from", "", "", "Generate from a YAML or JSON project spec file")
+	generateCmd.Flags().StringP("db", "", config.DatabasePostgres, "Database: "+strings.Join(config.Databases, ", "))
+	addGeneratorFlags(generateCmd)
 }
 
 func generateProject(cmd *cobra.Command, args []string) {
+	specFile, _ := cmd.Flags().GetString("from")
+	if specFile != "" {
+		projectConfig, err := loadSpec(cmd, args, specFile)
+		if err != nil {
+			fmt.Printf("❌ Error loading spec:\n%v\n", err)
+			os.Exit(1)
+		}
+		createProject(cmd, projectConfig)
+		return
+	}
+
 	var projectName string
 	if len(args) > 0 {
 		projectName = args[0]
@@ -50,12 +66,14 @@ func generateProject(cmd *cobra.Command, args []string) {
 
 	interactive, _ := cmd.Flags().GetBool("interactive")
 	minimal, _ := cmd.Flags().GetBool("minimal")
+	database, _ := cmd.Flags().GetString("db")
 
 	projectConfig := config.ProjectConfig{
 		Name:        projectName,
 		ModuleName:  moduleName,
 		Description: description,
 		Author:      author,
+		Database:    database,
 		Models:      []config.ModelConfig{},
 		Services:    []string{},
 	}
@@ -73,18 +91,64 @@ func generateProject(cmd *cobra.Command, args []string) {
 		projectConfig.Services = append(projectConfig.Services, prompts.PromptForServices()...)
 	}
 
-	fmt.Printf("Generating project '%s'...\n", projectName)
+	if err := spec.Validate(projectName, &projectConfig); err != nil {
+		fmt.Printf("❌ Invalid project:\n%v\n", err)
+		os.Exit(1)
+	}
+
+	createProject(cmd, projectConfig)
+}
+
+// loadSpec reads the project spec, letting the project name argument and
+// explicitly set flags override the values from the file
+func loadSpec(cmd *cobra.Command, args []string, specFile string) (config.ProjectConfig, error) {
+	projectConfig, err := spec.Load(specFile)
+	if err != nil {
+		return projectConfig, err
+	}
+
+	if len(args) > 0 {
+		projectConfig.Name = args[0]
+	}
+	if cmd.Flags().Changed("module") {
+		projectConfig.ModuleName, _ = cmd.Flags().GetString("module")
+	}
+	if cmd.Flags().Changed("author") {
+		projectConfig.Author, _ = cmd.Flags().GetString("author")
+	}
+	if cmd.Flags().Changed("description") {
+		projectConfig.Description, _ = cmd.Flags().GetString("description")
+	}
+	if cmd.Flags().Changed("db") {
+		projectConfig.Database, _ = cmd.Flags().GetString("db")
+		if err := spec.Validate(specFile, &projectConfig); err != nil {
+			return projectConfig, err
+		}
+	}
+
+	return projectConfig, nil
+}
 
-	gen := generator.New()
+func createProject(cmd *cobra.Command, projectConfig config.ProjectConfig) {
+	projectName := projectConfig.Name
+	opts := generatorOptions(cmd)
+	if !opts.DryRun {
+		fmt.Printf("Generating project '%s'...\n", projectName)
+	}
+
+	gen := generator.NewWithOptions(opts)
 	if err := gen.CreateProject(projectConfig); err != nil {
 		fmt.Printf("Error creating project: %v\n", err)
 		os.Exit(1)
 	}
+	if opts.DryRun {
+		return
+	}
 
 	fmt.Printf("✅ Project '%s' generated successfully!\n", projectName)
 	fmt.Printf("📁 Location: ./%s\n", projectName)
 	fmt.Println("\nNext steps:")
 	fmt.Printf("  cd %s\n", projectName)
 	fmt.Println("  go mod tidy")
-	fmt.Println("  go run main.go")
+	fmt.Println("  go run .")
 }
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import models from an existing database schema or API contract",
+}
+
+func init() {
+	importCmd.AddCommand(importSQLCmd, importDBCmd, importOpenAPICmd)
+}
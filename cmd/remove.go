@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+	"github.com/erwinhermantodev/hexa-go/internal/manifest"
+	"github.com/erwinhermantodev/hexa-go/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove components from an existing project",
+}
+
+var removeFieldCmd = &cobra.Command{
+	Use:   "field [model-name] [field-name...]",
+	Short: "Remove fields from an existing model and write a migration dropping their columns",
+	Long: `Remove fields from an existing model. A migration dropping their columns
+is written to migrations/ and the model files are regenerated. Files edited
+since they were generated are only replaced with --force or --ask,
+otherwise nothing is changed.`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  removeField,
+}
+
+func init() {
+	removeCmd.AddCommand(removeFieldCmd)
+	addGeneratorFlags(removeFieldCmd)
+}
+
+func removeField(cmd *cobra.Command, args []string) {
+	modelName := inflect.Pascal(args[0])
+
+	if !utils.FileExists("go.mod") {
+		fmt.Println("❌ No go.mod found. Please run this command in a Go project directory.")
+		return
+	}
+
+	projectConfig, err := loadProject()
+	if err != nil {
+		fmt.Printf("❌ Error loading %s: %v\n", manifest.FileName, err)
+		return
+	}
+
+	var names []string
+	for _, name := range args[1:] {
+		names = append(names, inflect.Snake(name))
+	}
+	updateModel(cmd, projectConfig, modelName, "remove_"+strings.Join(names, "_and_")+"_from_", func(model *config.ModelConfig) error {
+		for _, name := range args[1:] {
+			name = inflect.Pascal(name)
+			if model.IsKeyField(name) {
+				return fmt.Errorf("field '%s' is part of the primary key of '%s'", name, model.Name)
+			}
+			found := false
+			for i, field := range model.Fields {
+				if field.Name == name {
+					model.Fields = append(model.Fields[:i], model.Fields[i+1:]...)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("model '%s' has no field '%s'", model.Name, name)
+			}
+		}
+		return nil
+	})
+}
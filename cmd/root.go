@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/erwinhermantodev/hexa-go/internal/generator"
 	"github.com/spf13/cobra"
 )
 
@@ -16,5 +17,30 @@ func Execute() error {
 }
 
 func init() {
-	rootCmd.AddCommand(generateCmd, addCmd)
+	rootCmd.AddCommand(generateCmd, addCmd, removeCmd, importCmd, templatesCmd)
+}
+
+// addGeneratorFlags registers the flags shared by all generating commands
+func addGeneratorFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("dry-run", "", false, "Preview the generated files as a diff without writing them")
+	cmd.Flags().BoolP("force", "", false, "Overwrite existing files")
+	cmd.Flags().BoolP("skip-existing", "", false, "Keep existing files untouched (default)")
+	cmd.Flags().BoolP("ask", "", false, "Ask what to do for every existing file")
+	cmd.MarkFlagsMutuallyExclusive("force", "skip-existing", "ask")
+}
+
+// generatorOptions builds generator options from the shared flags
+func generatorOptions(cmd *cobra.Command) generator.Options {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+	ask, _ := cmd.Flags().GetBool("ask")
+
+	opts := generator.Options{DryRun: dryRun, Conflict: generator.ConflictSkip}
+	switch {
+	case force:
+		opts.Conflict = generator.ConflictOverwrite
+	case ask:
+		opts.Conflict = generator.ConflictAsk
+	}
+	return opts
 }
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/erwinhermantodev/hexa-go/internal/templates"
+	"github.com/erwinhermantodev/hexa-go/internal/utils"
+	"github.com/erwinhermantodev/hexa-go/internal/verify"
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Inspect and customize the code generation templates",
+}
+
+var templatesExportCmd = &cobra.Command{
+	Use:   "export [dir]",
+	Short: "Write the built-in templates to disk as editable .tmpl files",
+	Long: `Write the built-in templates to disk as editable .tmpl files.
+
+Templates are looked up by name in the project's .hexa/templates directory,
+then in ~/.config/hexa-go/templates, before falling back to the built-in
+set. Exported files can be edited in place or deleted to restore the
+built-in version.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  exportTemplates,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List templates and where each one is loaded from",
+	Args:  cobra.NoArgs,
+	Run:   listTemplates,
+}
+
+var templatesVerifyCmd = &cobra.Command{
+	Use:   "verify [case...]",
+	Short: "Generate sample projects and check that they compile",
+	Long: `Generate sample projects from a matrix of configurations and type-check
+every generated package against stubs of the third-party dependencies.
+
+The stubs are not part of the binary: run the command from a hexa-go
+checkout or point --stubs at a copy of internal/verify/testdata/modcache.
+Template overrides in ~/.config/hexa-go/templates are used, so customized
+templates can be checked before generating real projects. No network
+access or Go toolchain downloads are needed.`,
+	Run: verifyTemplates,
+}
+
+func init() {
+	templatesExportCmd.Flags().BoolP("user", "", false, "Export to ~/.config/hexa-go/templates instead of the project")
+	templatesExportCmd.Flags().BoolP("force", "", false, "Overwrite templates that were already exported")
+	templatesVerifyCmd.Flags().StringP("stubs", "", filepath.Join("internal", "verify", "testdata", "modcache"), "Directory with the stubs of third-party packages")
+	templatesCmd.AddCommand(templatesExportCmd, templatesListCmd, templatesVerifyCmd)
+}
+
+func exportTemplates(cmd *cobra.Command, args []string) {
+	user, _ := cmd.Flags().GetBool("user")
+	force, _ := cmd.Flags().GetBool("force")
+
+	dirs := templates.SearchDirs(".")
+	dir := dirs[0]
+	switch {
+	case len(args) > 0:
+		dir = args[0]
+	case user:
+		if len(dirs) < 2 {
+			fmt.Println("❌ Could not determine the user config directory")
+			return
+		}
+		dir = dirs[1]
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Printf("❌ Error creating %s: %v\n", dir, err)
+		return
+	}
+
+	var written, skipped int
+	for _, name := range templates.Names() {
+		filePath := filepath.Join(dir, name+templates.Ext)
+		if !force && utils.FileExists(filePath) {
+			skipped++
+			continue
+		}
+		content, err := templates.Builtin(name)
+		if err != nil {
+			fmt.Printf("❌ Error reading template %s: %v\n", name, err)
+			return
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			fmt.Printf("❌ Error writing %s: %v\n", filePath, err)
+			return
+		}
+		written++
+	}
+
+	fmt.Printf("✅ Exported %d templates to %s\n", written, dir)
+	if skipped > 0 {
+		fmt.Printf("  ⏭️  Skipped %d existing templates (use --force to overwrite)\n", skipped)
+	}
+}
+
+func listTemplates(cmd *cobra.Command, args []string) {
+	dirs := templates.SearchDirs(".")
+	for _, name := range templates.Names() {
+		_, source, err := templates.Lookup(name, dirs)
+		if err != nil {
+			source = err.Error()
+		}
+		fmt.Printf("  %-22s %s\n", name, source)
+	}
+}
+
+func verifyTemplates(cmd *cobra.Command, args []string) {
+	stubs, _ := cmd.Flags().GetString("stubs")
+	if !utils.FileExists(stubs) {
+		fmt.Printf("❌ Stubs not found in %s (run from a hexa-go checkout or use --stubs)\n", stubs)
+		os.Exit(1)
+	}
+
+	cases, err := verify.Matrix()
+	if err != nil {
+		fmt.Printf("❌ Error building the verification matrix: %v\n", err)
+		os.Exit(1)
+	}
+	if len(args) > 0 {
+		var selected []verify.Case
+		for _, name := range args {
+			found := false
+			for _, c := range cases {
+				if c.Name == name {
+					selected = append(selected, c)
+					found = true
+				}
+			}
+			if !found {
+				fmt.Printf("❌ Unknown case %s\n", name)
+				os.Exit(1)
+			}
+		}
+		cases = selected
+	}
+
+	if err := verify.Run(os.Stdout, os.DirFS(stubs), cases); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("✅ All generated projects compile")
+}
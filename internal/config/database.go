@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Databases supported by generated projects
+const (
+	DatabasePostgres  = "postgres"
+	DatabaseMySQL     = "mysql"
+	DatabaseSQLite    = "sqlite"
+	DatabaseSQLServer = "sqlserver"
+)
+
+// Databases lists the supported databases
+var Databases = []string{DatabasePostgres, DatabaseMySQL, DatabaseSQLite, DatabaseSQLServer}
+
+// driverModules maps databases to the GORM driver module and version
+// generated projects depend on. SQLite uses a pure Go driver so projects
+// build without cgo.
+var driverModules = map[string][2]string{
+	DatabasePostgres:  {"gorm.io/driver/postgres", "v1.5.2"},
+	DatabaseMySQL:     {"gorm.io/driver/mysql", "v1.5.1"},
+	DatabaseSQLite:    {"github.com/glebarez/sqlite", "v1.9.0"},
+	DatabaseSQLServer: {"gorm.io/driver/sqlserver", "v1.5.1"},
+}
+
+// ParseDatabase returns the database matching s, accepting a few common
+// aliases
+func ParseDatabase(s string) (string, bool) {
+	switch db := strings.ToLower(strings.TrimSpace(s)); db {
+	case "", "postgresql", "pg":
+		return DatabasePostgres, true
+	case "sqlite3":
+		return DatabaseSQLite, true
+	case "mssql":
+		return DatabaseSQLServer, true
+	default:
+		_, ok := driverModules[db]
+		return db, ok
+	}
+}
+
+// DB returns the database of the project, Postgres by default
+func (p ProjectConfig) DB() string {
+	if db, ok := ParseDatabase(p.Database); ok {
+		return db
+	}
+	return DatabasePostgres
+}
+
+// DatabaseTitle returns the display name of the project database
+func (p ProjectConfig) DatabaseTitle() string {
+	switch p.DB() {
+	case DatabaseMySQL:
+		return "MySQL"
+	case DatabaseSQLite:
+		return "SQLite"
+	case DatabaseSQLServer:
+		return "SQL Server"
+	}
+	return "PostgreSQL"
+}
+
+// DriverModule returns the module of the GORM driver of the project
+func (p ProjectConfig) DriverModule() string {
+	return driverModules[p.DB()][0]
+}
+
+// DriverVersion returns the required version of the GORM driver module
+func (p ProjectConfig) DriverVersion() string {
+	return driverModules[p.DB()][1]
+}
+
+// DatabasePort returns the default port of the project database server
+func (p ProjectConfig) DatabasePort() string {
+	switch p.DB() {
+	case DatabaseMySQL:
+		return "3306"
+	case DatabaseSQLServer:
+		return "1433"
+	}
+	return "5432"
+}
+
+// DatabaseUser returns the default user of the project database server
+func (p ProjectConfig) DatabaseUser() string {
+	switch p.DB() {
+	case DatabaseMySQL:
+		return "root"
+	case DatabaseSQLServer:
+		return "sa"
+	}
+	return "postgres"
+}
+
+// DatabasePassword returns the default password of the project database
+// server in development. SQL Server rejects passwords that are too simple.
+func (p ProjectConfig) DatabasePassword() string {
+	switch p.DB() {
+	case DatabaseMySQL:
+		return "root"
+	case DatabaseSQLServer:
+		return "Passw0rd!"
+	}
+	return "postgres"
+}
+
+// MigrateURL returns the database URL golang-migrate runs the migrations
+// of the project against in development
+func (p ProjectConfig) MigrateURL() string {
+	switch p.DB() {
+	case DatabaseMySQL:
+		return fmt.Sprintf("mysql://%s:%s@tcp(localhost:%s)/%s?multiStatements=true", p.DatabaseUser(), p.DatabasePassword(), p.DatabasePort(), p.Name)
+	case DatabaseSQLite:
+		return fmt.Sprintf("sqlite3://%s.db", p.Name)
+	case DatabaseSQLServer:
+		return fmt.Sprintf("sqlserver://%s:%s@localhost:%s?database=%s", p.DatabaseUser(), url.QueryEscape(p.DatabasePassword()), p.DatabasePort(), p.Name)
+	}
+	return fmt.Sprintf("postgres://%s:%s@localhost:%s/%s?sslmode=disable", p.DatabaseUser(), p.DatabasePassword(), p.DatabasePort(), p.Name)
+}
+
+// StructTag returns the struct tag of a field of a model with column types
+// that only exist in Postgres replaced by their equivalent in the project
+// database
+func (p ProjectConfig) StructTag(model ModelConfig, field FieldConfig) string {
+	tag := model.StructTag(field)
+	if p.DB() == DatabasePostgres {
+		return tag
+	}
+
+	// jsonb comes first as the replacer prefers earlier arguments
+	return strings.NewReplacer(
+		"type:uuid", "type:"+p.columnType("uuid"),
+		"type:jsonb", "type:"+p.columnType("jsonb"),
+		"type:json", "type:"+p.columnType("json"),
+	).Replace(tag)
+}
+
+// columnType returns the column type standing in for a Postgres type
+func (p ProjectConfig) columnType(postgres string) string {
+	switch {
+	case postgres == "uuid":
+		// UUIDs are read and written in their text form
+		return "char(36)"
+	case p.DB() == DatabaseMySQL:
+		return "json"
+	case p.DB() == DatabaseSQLServer:
+		return "nvarchar(max)"
+	}
+	return "text"
+}
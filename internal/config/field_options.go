@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+)
+
+// FieldOptions lists the option keywords of the field DSL
+var FieldOptions = []string{"default=<value>", "unique", "index", "column=<name>", "size=<n>", "readonly", "writeonly"}
+
+// ParseOptions parses a comma separated list of field options such as
+// unique,size=255,default=draft,writeonly
+func (f *FieldConfig) ParseOptions(s string) error {
+	for _, option := range strings.Split(s, ",") {
+		if option = strings.TrimSpace(option); option != "" {
+			if err := f.ParseOption(option); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ParseOption applies a single field option keyword
+func (f *FieldConfig) ParseOption(option string) error {
+	key, value, hasValue := strings.Cut(option, "=")
+	key = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(key)))
+	value = strings.TrimSpace(value)
+
+	switch {
+	case key == "default" && hasValue:
+		f.Default = value
+	case key == "column" && hasValue:
+		f.Column = value
+	case key == "size" && hasValue:
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid size %q", value)
+		}
+		f.Size = size
+	case key == "unique" && !hasValue:
+		f.Unique = true
+	case key == "index" && !hasValue:
+		f.Index = true
+	case key == "readonly" && !hasValue:
+		f.ReadOnly = true
+	case key == "writeonly" && !hasValue:
+		f.WriteOnly = true
+	default:
+		return fmt.Errorf("unknown field option %q (use %s)", option, strings.Join(FieldOptions, ", "))
+	}
+	return nil
+}
+
+// IsOption reports whether s is a field option keyword
+func IsOption(s string) bool {
+	var f FieldConfig
+	return f.ParseOption(s) == nil
+}
+
+// CheckOptions reports field options that cannot be generated
+func (f FieldConfig) CheckOptions() error {
+	switch {
+	case f.ReadOnly && f.WriteOnly:
+		return fmt.Errorf("cannot be both read_only and write_only")
+	case f.Column != "" && (strings.TrimSpace(f.Column) != f.Column || strings.ContainsAny(f.Column, ";\"`\\")):
+		return fmt.Errorf("column %q cannot contain ; \" ` \\ or surrounding spaces", f.Column)
+	case f.Size < 0:
+		return fmt.Errorf("size must be positive")
+	case f.Size > 0 && !f.isString():
+		return fmt.Errorf("size only applies to string fields")
+	case strings.ContainsAny(f.Default, ";\"`"):
+		return fmt.Errorf("default %q cannot contain ; \" or `", f.Default)
+	}
+	return nil
+}
+
+// ColumnName returns the database column of the field
+func (f FieldConfig) ColumnName() string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return inflect.Snake(f.Name)
+}
+
+// JSONKey returns the JSON key of the field in requests and responses
+func (f FieldConfig) JSONKey() string {
+	if f.JSONName != "" {
+		return f.JSONName
+	}
+	return inflect.Snake(f.Name)
+}
+
+// InRequest reports whether the field is accepted in create and update
+// requests. The primary key and timestamps are always managed by the server.
+func (f FieldConfig) InRequest() bool {
+	switch f.Name {
+	case "ID", "CreatedAt", "UpdatedAt", "DeletedAt":
+		return false
+	}
+	return !f.ReadOnly
+}
+
+// InResponse reports whether the field is returned in responses. Fields
+// hidden from JSON on the model are treated as write-only.
+func (f FieldConfig) InResponse() bool {
+	return !f.WriteOnly && !strings.Contains(f.Tag, `json:"-"`)
+}
+
+func (f FieldConfig) isString() bool {
+	return f.Type == "string" && f.Collection == ""
+}
+
+// optionTags returns the GORM options set by the field options, skipping
+// those already written in the tag
+func optionTags(field FieldConfig) []string {
+	var options []string
+	if field.Column != "" && !hasGormOption(field.Tag, "column") {
+		options = append(options, "column:"+field.Column)
+	}
+	if field.Size > 0 && !hasGormOption(field.Tag, "size") {
+		options = append(options, "size:"+strconv.Itoa(field.Size))
+	}
+	if field.Default != "" && !hasGormOption(field.Tag, "default") {
+		options = append(options, "default:"+field.Default)
+	}
+	if field.Unique && !hasGormOption(field.Tag, "unique") && !hasGormOption(field.Tag, "uniqueIndex") {
+		options = append(options, "unique")
+	}
+	if field.Index && !hasGormOption(field.Tag, "index") {
+		options = append(options, "index")
+	}
+	return options
+}
+
+// hasGormOption reports whether the gorm key of tag sets option
+func hasGormOption(tag, option string) bool {
+	i := strings.Index(tag, `gorm:"`)
+	if i < 0 {
+		return false
+	}
+	rest := tag[i+len(`gorm:"`):]
+	if end := strings.Index(rest, `"`); end >= 0 {
+		rest = rest[:end]
+	}
+	for _, part := range strings.Split(rest, ";") {
+		key, _, _ := strings.Cut(part, ":")
+		if strings.EqualFold(strings.TrimSpace(key), option) {
+			return true
+		}
+	}
+	return false
+}
+
+// Column returns the database column of the named field of the model
+func (m ModelConfig) Column(name string) string {
+	for _, field := range m.Fields {
+		if field.Name == name {
+			return field.ColumnName()
+		}
+	}
+	return inflect.Snake(name)
+}
+
+// hideJSON sets the json key of a backquoted struct tag to "-"
+func hideJSON(tag string) string {
+	tag = strings.Trim(strings.TrimSpace(tag), "`")
+	if i := strings.Index(tag, `json:"`); i >= 0 {
+		start := i + len(`json:"`)
+		if end := strings.Index(tag[start:], `"`); end >= 0 {
+			return "`" + tag[:start] + "-" + tag[start+end:] + "`"
+		}
+	}
+	if tag == "" {
+		return "`json:\"-\"`"
+	}
+	return "`" + tag + " json:\"-\"`"
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Field types with generated storage, besides plain Go types and enums
+const (
+	FieldJSON    = "json"
+	FieldJSONB   = "jsonb"
+	FieldDecimal = "decimal"
+	FieldMoney   = "money"
+)
+
+// Collection kinds of a field
+const (
+	CollectionSlice = "slice"
+	CollectionMap   = "map"
+)
+
+// sqlNullTypes maps the database/sql null wrappers, which serialize as
+// {"String":"","Valid":false}, onto the value type of a nullable field
+var sqlNullTypes = map[string]string{
+	"sql.NullString":  "string",
+	"sql.NullInt16":   "int16",
+	"sql.NullInt32":   "int32",
+	"sql.NullInt64":   "int64",
+	"sql.NullByte":    "uint8",
+	"sql.NullFloat64": "float64",
+	"sql.NullBool":    "bool",
+	"sql.NullTime":    "time.Time",
+}
+
+// ParseType normalizes a Go-like type written in the field DSL, such as
+// *string, []string, map[string]int, jsonb, decimal(12,2) or
+// enum(a,b), into the typed field model. []byte stays a plain type and
+// sql.NullString and the other null wrappers become nullable fields. Types
+// that are already normalized are left unchanged.
+func (f *FieldConfig) ParseType() error {
+	t := strings.TrimSpace(f.Type)
+
+	switch {
+	case strings.HasPrefix(t, "*"):
+		f.Nullable = true
+		t = strings.TrimSpace(t[1:])
+	case strings.HasPrefix(t, "[]") && t != "[]byte":
+		f.Collection = CollectionSlice
+		t = strings.TrimSpace(t[2:])
+	case strings.HasPrefix(t, "map[string]"):
+		f.Collection = CollectionMap
+		t = strings.TrimSpace(t[len("map[string]"):])
+	}
+	if base, ok := sqlNullTypes[t]; ok {
+		f.Nullable = true
+		t = base
+	}
+	if f.Collection != "" {
+		f.Nullable = false
+	}
+
+	if values, ok := ParseEnumType(t); ok {
+		f.Type, f.Enum = FieldEnum, values
+		return nil
+	}
+
+	switch lower := strings.ToLower(t); {
+	case lower == FieldJSON || lower == FieldJSONB || lower == FieldMoney:
+		t = lower
+	case lower == "datatypes.json":
+		t = FieldJSON
+	case lower == "decimal.decimal" || lower == FieldDecimal:
+		t = FieldDecimal
+	case strings.HasPrefix(lower, FieldDecimal+"(") && strings.HasSuffix(lower, ")"):
+		precision, scale, ok := strings.Cut(lower[len(FieldDecimal)+1:len(lower)-1], ",")
+		p, err := strconv.Atoi(strings.TrimSpace(precision))
+		if err != nil || p <= 0 {
+			return fmt.Errorf("invalid decimal precision in %q", f.Type)
+		}
+		f.Precision = p
+		if ok {
+			s, err := strconv.Atoi(strings.TrimSpace(scale))
+			if err != nil || s < 0 || s > p {
+				return fmt.Errorf("invalid decimal scale in %q", f.Type)
+			}
+			f.Scale = s
+		}
+		t = FieldDecimal
+	}
+
+	if f.Collection != "" && (t == FieldJSON || t == FieldJSONB) {
+		return fmt.Errorf("%s fields cannot be collections, the column already holds any JSON value", t)
+	}
+	f.Type = t
+	return nil
+}
+
+// IsSpecial reports whether the field type is one of the generated types
+// rather than a Go type
+func (f FieldConfig) IsSpecial() bool {
+	switch f.Type {
+	case FieldEnum, FieldJSON, FieldJSONB, FieldDecimal, FieldMoney:
+		return true
+	}
+	return false
+}
+
+// OmitEmpty reports whether the field may be absent from requests and
+// responses
+func (f FieldConfig) OmitEmpty() bool {
+	return f.Nullable || f.Collection != "" || f.Type == FieldJSON || f.Type == FieldJSONB
+}
+
+// baseType returns the Go type of a single value of the field
+func (m ModelConfig) baseType(field FieldConfig) string {
+	switch field.Type {
+	case FieldEnum:
+		return m.EnumType(field)
+	case FieldJSON, FieldJSONB:
+		return "datatypes.JSON"
+	case FieldDecimal, FieldMoney:
+		return "decimal.Decimal"
+	}
+	return field.Type
+}
+
+// typeOptions returns the GORM options implied by the field type
+func typeOptions(field FieldConfig) []string {
+	var options []string
+	hasType := strings.Contains(field.Tag, "type:")
+
+	if field.Collection != "" && !strings.Contains(field.Tag, "serializer:") {
+		options = append(options, "serializer:json")
+	}
+	if hasType {
+		return options
+	}
+
+	switch field.Type {
+	case FieldJSON:
+		options = append(options, "type:json")
+	case FieldJSONB:
+		options = append(options, "type:jsonb")
+	case FieldMoney:
+		options = append(options, "type:numeric(19,4)")
+	case FieldDecimal:
+		switch {
+		case field.Precision > 0:
+			options = append(options, fmt.Sprintf("type:numeric(%d,%d)", field.Precision, field.Scale))
+		default:
+			options = append(options, "type:numeric")
+		}
+	}
+	return options
+}
+
+// typeImports maps package qualifiers used in generated types to their
+// import paths
+var typeImports = map[string]string{
+	"time.":      "time",
+	"json.":      "encoding/json",
+	"sql.":       "database/sql",
+	"gorm.":      "gorm.io/gorm",
+	"datatypes.": "gorm.io/datatypes",
+	"decimal.":   "github.com/shopspring/decimal",
+	"uuid.":      "github.com/google/uuid",
+}
+
+// ModelImports returns the packages the model file of a model needs for
+// its field types, enums, relations and primary key, grouped into standard
+// library and third-party imports
+func (p ProjectConfig) ModelImports(model ModelConfig) [][]string {
+	seen := map[string]bool{}
+	addType := func(t string) {
+		for qualifier, path := range typeImports {
+			if strings.Contains(t, qualifier) {
+				seen[path] = true
+			}
+		}
+	}
+
+	for _, field := range model.Fields {
+		addType(model.GoType(field))
+		if field.IsEnum() {
+			seen["encoding/json"] = true
+			seen["fmt"] = true
+		}
+	}
+	for _, relation := range model.Relations {
+		addType(p.RelationIDType(relation))
+	}
+	switch model.KeyStrategy() {
+	case KeyUUID, KeyUUIDv7:
+		if model.PrimaryKey != "" {
+			seen["gorm.io/gorm"] = true
+			seen["github.com/google/uuid"] = true
+		}
+	case KeyULID:
+		seen["gorm.io/gorm"] = true
+		seen["github.com/oklog/ulid/v2"] = true
+	}
+
+	var std, thirdParty []string
+	for path := range seen {
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			thirdParty = append(thirdParty, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(thirdParty)
+
+	var groups [][]string
+	for _, group := range [][]string{std, thirdParty} {
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// Uses reports whether any generated model imports the given package
+func (p ProjectConfig) Uses(importPath string) bool {
+	for _, model := range p.Models {
+		for _, group := range p.ModelImports(model) {
+			for _, path := range group {
+				if path == importPath {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+)
+
+// Primary key strategies supported by ModelConfig.PrimaryKey
+const (
+	KeyUint      = "uint"
+	KeyInt64     = "int64"
+	KeyUUID      = "uuid"
+	KeyUUIDv7    = "uuidv7"
+	KeyULID      = "ulid"
+	KeyComposite = "composite"
+)
+
+// KeyStrategies lists the supported primary key strategies
+var KeyStrategies = []string{KeyUint, KeyInt64, KeyUUID, KeyUUIDv7, KeyULID, KeyComposite + "(A,B)"}
+
+// ParsePrimaryKey splits a primary key declaration such as uuid or
+// composite(TenantID,Code) into its strategy and composite fields
+func ParsePrimaryKey(s string) (string, []string, bool) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "", KeyUint:
+		return KeyUint, nil, true
+	case KeyInt64, KeyUUID, KeyUUIDv7, KeyULID:
+		return strings.ToLower(s), nil, true
+	}
+
+	if !strings.HasPrefix(s, KeyComposite+"(") || !strings.HasSuffix(s, ")") {
+		return "", nil, false
+	}
+	var fields []string
+	for _, field := range strings.Split(s[len(KeyComposite)+1:len(s)-1], ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return KeyComposite, fields, len(fields) > 0
+}
+
+// KeyStrategy returns the primary key strategy of the model
+func (m ModelConfig) KeyStrategy() string {
+	strategy, _, _ := ParsePrimaryKey(m.PrimaryKey)
+	return strategy
+}
+
+// IsCompositeKey reports whether the model has a composite primary key
+func (m ModelConfig) IsCompositeKey() bool {
+	return m.KeyStrategy() == KeyComposite
+}
+
+// KeyFields returns the primary key fields of the model
+func (m ModelConfig) KeyFields() []string {
+	if _, fields, _ := ParsePrimaryKey(m.PrimaryKey); len(fields) > 0 {
+		return fields
+	}
+	return []string{"ID"}
+}
+
+// KeyField returns the primary key field with the given name
+func (m ModelConfig) KeyField(name string) FieldConfig {
+	for _, field := range m.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return FieldConfig{Name: name, Type: KeyUint}
+}
+
+// KeyType returns the name of the struct generated in the model package
+// for a composite primary key
+func (m ModelConfig) KeyType() string {
+	return m.Name + "Key"
+}
+
+// IDType returns the Go type identifying a model outside of the model
+// package
+func (m ModelConfig) IDType() string {
+	switch m.KeyStrategy() {
+	case KeyComposite:
+		return "model." + m.KeyType()
+	case KeyUint:
+		if !m.hasField("ID") {
+			return KeyUint
+		}
+		return m.KeyField("ID").Type
+	}
+	return keyFieldType(m.KeyStrategy())
+}
+
+// IDParams returns the route parameters identifying a model
+func (m ModelConfig) IDParams() []string {
+	if !m.IsCompositeKey() {
+		return []string{"id"}
+	}
+	var params []string
+	for _, field := range m.KeyFields() {
+		params = append(params, inflect.Snake(field))
+	}
+	return params
+}
+
+// ApplyPrimaryKey makes the ID field match an explicit primary key
+// strategy, adding the field if the model does not declare it. Without a
+// strategy, a uint ID is only added to models with layers, which look
+// records up by id.
+func (m *ModelConfig) ApplyPrimaryKey() {
+	strategy := m.KeyStrategy()
+	switch {
+	case strategy == KeyComposite:
+		return
+	case m.PrimaryKey == "":
+		if m.hasField("ID") || !m.hasLayers() {
+			return
+		}
+	default:
+		m.PrimaryKey = strategy
+	}
+
+	for i := range m.Fields {
+		if m.Fields[i].Name == "ID" {
+			m.Fields[i].Type = keyFieldType(strategy)
+			return
+		}
+	}
+	id := FieldConfig{Name: "ID", Type: keyFieldType(strategy), Tag: "`json:\"id\"`"}
+	m.Fields = append([]FieldConfig{id}, m.Fields...)
+}
+
+// HasKey reports whether the model has a single column primary key other
+// models can refer to, once ApplyPrimaryKey has run
+func (m ModelConfig) HasKey() bool {
+	if m.PrimaryKey != "" {
+		return !m.IsCompositeKey()
+	}
+	return m.hasField("ID") || m.hasLayers()
+}
+
+// hasLayers reports whether a repository, service or handler is generated
+// for the model
+func (m ModelConfig) hasLayers() bool {
+	return !m.Embedded && (m.HasRepo || m.HasService || m.HasHandler)
+}
+
+// keyOptions returns the GORM options a primary key field needs
+func (m ModelConfig) keyOptions(field FieldConfig) []string {
+	if m.PrimaryKey == "" || !m.IsKeyField(field.Name) {
+		return nil
+	}
+
+	var options []string
+	tag := strings.ToLower(field.Tag)
+	if !strings.Contains(tag, "primarykey") {
+		options = append(options, "primaryKey")
+	}
+	if m.IsCompositeKey() && !strings.Contains(tag, "autoincrement") {
+		options = append(options, "autoIncrement:false")
+	}
+	if !strings.Contains(tag, "type:") {
+		switch m.KeyStrategy() {
+		case KeyUUID, KeyUUIDv7:
+			options = append(options, "type:uuid")
+		case KeyULID:
+			options = append(options, "type:char(26)")
+		}
+	}
+	return options
+}
+
+// IsKeyField reports whether the named field is part of the primary key
+func (m ModelConfig) IsKeyField(name string) bool {
+	for _, field := range m.KeyFields() {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m ModelConfig) hasField(name string) bool {
+	for _, field := range m.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// keyFieldType returns the Go type of the ID field for a strategy. ULIDs
+// are stored in their canonical text form.
+func keyFieldType(strategy string) string {
+	switch strategy {
+	case KeyInt64:
+		return "int64"
+	case KeyUUID, KeyUUIDv7:
+		return "uuid.UUID"
+	case KeyULID:
+		return "string"
+	}
+	return KeyUint
+}
+
+// CheckKeyField reports whether a field type can be part of a composite
+// primary key, which is parsed from the request path
+func CheckKeyField(field FieldConfig) error {
+	if field.Nullable || field.Collection != "" {
+		return fmt.Errorf("field %q cannot be part of a composite primary key, it is nullable or a collection", field.Name)
+	}
+	switch field.Type {
+	case "string", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return nil
+	}
+	return fmt.Errorf("field %q of type %s cannot be part of a composite primary key", field.Name, field.Type)
+}
+
+// UsesKey reports whether any model uses one of the primary key strategies
+func (p ProjectConfig) UsesKey(strategies ...string) bool {
+	for _, model := range p.Models {
+		for _, strategy := range strategies {
+			if model.PrimaryKey != "" && model.KeyStrategy() == strategy {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RelationIDType returns the Go type of the keys referencing the target
+// of a relation
+func (p ProjectConfig) RelationIDType(relation RelationConfig) string {
+	if target, ok := p.FindModel(relation.Target()); ok {
+		return target.IDType()
+	}
+	return KeyUint
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+)
+
+// CRUD endpoints of a model handler
+const (
+	EndpointCreate = "create"
+	EndpointGet    = "get"
+	EndpointList   = "list"
+	EndpointUpdate = "update"
+	EndpointDelete = "delete"
+)
+
+// Endpoints lists the CRUD endpoints a model handler can expose
+var Endpoints = []string{EndpointCreate, EndpointGet, EndpointList, EndpointUpdate, EndpointDelete}
+
+// Access to the routes of a model handler: mixed keeps the routes reading
+// records public and protects those writing them
+const (
+	AuthPublic    = "public"
+	AuthProtected = "protected"
+	AuthMixed     = "mixed"
+)
+
+// AuthModes lists the access modes of the routes of a model handler
+var AuthModes = []string{AuthPublic, AuthProtected, AuthMixed}
+
+// HTTPMethods lists the methods operations can use
+var HTTPMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// OperationConfig is a handler method beyond CRUD, such as an endpoint
+// imported from an OpenAPI document
+type OperationConfig struct {
+	// Handler is the model or standalone handler the method belongs to
+	Handler string `yaml:"handler" json:"handler"`
+	Name    string `yaml:"name" json:"name"`
+	Method  string `yaml:"method" json:"method"`
+	// Path is relative to the API group, with {param} placeholders
+	Path    string `yaml:"path" json:"path"`
+	Summary string `yaml:"summary,omitempty" json:"summary,omitempty"`
+}
+
+var pathParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// EchoPath returns the path with :param placeholders as Echo expects
+func (o OperationConfig) EchoPath() string {
+	return pathParam.ReplaceAllString(o.Path, ":$1")
+}
+
+// Params returns the path parameters of the operation
+func (o OperationConfig) Params() []string {
+	var params []string
+	for _, m := range pathParam.FindAllStringSubmatch(o.Path, -1) {
+		params = append(params, m[1])
+	}
+	return params
+}
+
+// HasEndpoint reports whether the model handler exposes the CRUD endpoint.
+// Models without an endpoint list expose all of them.
+func (m ModelConfig) HasEndpoint(endpoint string) bool {
+	if len(m.Endpoints) == 0 {
+		return true
+	}
+	for _, e := range m.Endpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// HasItemEndpoint reports whether the model handler has an endpoint
+// addressing a single record by its key
+func (m ModelConfig) HasItemEndpoint() bool {
+	return m.HasEndpoint(EndpointGet) || m.HasEndpoint(EndpointUpdate) || m.HasEndpoint(EndpointDelete)
+}
+
+// AuthMode returns the access mode of the routes of the model handler,
+// public by default
+func (m ModelConfig) AuthMode() string {
+	if m.Auth == "" {
+		return AuthPublic
+	}
+	return m.Auth
+}
+
+// RoutePath returns the collection path of the model, relative to the API
+// group
+func (m ModelConfig) RoutePath() string {
+	if m.Path != "" {
+		return "/" + strings.Trim(m.Path, "/")
+	}
+	return "/" + inflect.Kebab(inflect.Plural(m.Name))
+}
+
+// HandlerMethods returns the names of the CRUD methods of the model handler
+func (m ModelConfig) HandlerMethods() []string {
+	return []string{
+		"Create" + m.Name,
+		"Get" + m.Name,
+		"GetAll" + inflect.Plural(m.Name),
+		"Update" + m.Name,
+		"Delete" + m.Name,
+	}
+}
+
+// HandlerOperations returns the operations of the named handler
+func (p ProjectConfig) HandlerOperations(handler string) []OperationConfig {
+	var operations []OperationConfig
+	for _, op := range p.Operations {
+		if op.Handler == handler {
+			operations = append(operations, op)
+		}
+	}
+	return operations
+}
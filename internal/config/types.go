@@ -1,30 +1,427 @@
 package config
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+)
+
 // ProjectConfig represents the configuration for a Go project
 type ProjectConfig struct {
-	Name        string
-	ModuleName  string
-	Description string
-	Author      string
-	Models      []ModelConfig
-	Services    []string
+	Name        string `yaml:"name" json:"name"`
+	ModuleName  string `yaml:"module" json:"module"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Author      string `yaml:"author,omitempty" json:"author,omitempty"`
+	// Database is postgres (default), mysql, sqlite or sqlserver
+	Database string        `yaml:"database,omitempty" json:"database,omitempty"`
+	Models   []ModelConfig `yaml:"models,omitempty" json:"models,omitempty"`
+	Services []string      `yaml:"services,omitempty" json:"services,omitempty"`
+	Handlers []string      `yaml:"handlers,omitempty" json:"handlers,omitempty"`
+	// Operations are handler methods beyond CRUD
+	Operations []OperationConfig `yaml:"operations,omitempty" json:"operations,omitempty"`
+
+	// Dir is the directory the project is generated into. It is never
+	// persisted; when empty the project name is used.
+	Dir string `yaml:"-" json:"-"`
+}
+
+// OutputDir returns the directory generated files are written to
+func (p ProjectConfig) OutputDir() string {
+	if p.Dir != "" {
+		return p.Dir
+	}
+	if p.Name != "" {
+		return p.Name
+	}
+	return "."
+}
+
+// FindModel returns the model with the given name
+func (p ProjectConfig) FindModel(name string) (ModelConfig, bool) {
+	for _, model := range p.Models {
+		if model.Name == name {
+			return model, true
+		}
+	}
+	return ModelConfig{}, false
+}
+
+// ModelNames returns the names of the models in order
+func (p ProjectConfig) ModelNames() []string {
+	names := make([]string, len(p.Models))
+	for i, model := range p.Models {
+		names[i] = model.Name
+	}
+	return names
+}
+
+// HasService reports whether a service with the given name exists, either
+// standalone or generated for a model
+func (p ProjectConfig) HasService(name string) bool {
+	for _, service := range p.Services {
+		if service == name {
+			return true
+		}
+	}
+	model, ok := p.FindModel(name)
+	return ok && model.HasService
+}
+
+// HasHandler reports whether a handler with the given name exists, either
+// standalone or generated for a model
+func (p ProjectConfig) HasHandler(name string) bool {
+	for _, handler := range p.Handlers {
+		if handler == name {
+			return true
+		}
+	}
+	model, ok := p.FindModel(name)
+	return ok && model.HasHandler
+}
+
+// WiresService reports whether the composition root of the project can
+// construct the named service. Model services need the repository
+// implementation of their model.
+func (p ProjectConfig) WiresService(name string) bool {
+	if model, ok := p.FindModel(name); ok && model.HasService {
+		return model.HasRepo
+	}
+	return p.HasService(name)
+}
+
+// WiresHandler reports whether the composition root of the project can
+// construct the named handler, which needs its service if it has one
+func (p ProjectConfig) WiresHandler(name string) bool {
+	return p.HasHandler(name) && (!p.HasService(name) || p.WiresService(name))
 }
 
 // ModelConfig represents configuration for a model
 type ModelConfig struct {
-	Name       string
-	Fields     []FieldConfig
-	HasRepo    bool
-	HasService bool
-	HasHandler bool
+	Name      string           `yaml:"name" json:"name"`
+	Fields    []FieldConfig    `yaml:"fields,omitempty" json:"fields,omitempty"`
+	Relations []RelationConfig `yaml:"relations,omitempty" json:"relations,omitempty"`
+	// PrimaryKey is the primary key strategy: uint (default), int64, uuid,
+	// uuidv7, ulid or composite(FieldA,FieldB)
+	PrimaryKey string `yaml:"primary_key,omitempty" json:"primary_key,omitempty"`
+	// Table overrides the table name derived from the model name
+	Table string `yaml:"table,omitempty" json:"table,omitempty"`
+	// Path overrides the route path derived from the model name
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+	// Endpoints limits the CRUD endpoints of the handler, all by default
+	Endpoints []string `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+	// Auth is public (default), protected, which puts the routes of the
+	// handler behind the JWT middleware, or mixed, which only protects the
+	// routes writing records
+	Auth string `yaml:"auth,omitempty" json:"auth,omitempty"`
+	// Embedded models are value structs stored in the table of the models
+	// embedding them, without a table or layers of their own
+	Embedded   bool `yaml:"embedded,omitempty" json:"embedded,omitempty"`
+	HasRepo    bool `yaml:"has_repo" json:"has_repo"`
+	HasService bool `yaml:"has_service" json:"has_service"`
+	HasHandler bool `yaml:"has_handler" json:"has_handler"`
 }
 
 // FieldConfig represents configuration for a model field
 type FieldConfig struct {
-	Name     string
-	Type     string
-	Tag      string
-	Validate string
+	Name     string `yaml:"name" json:"name"`
+	Type     string `yaml:"type" json:"type"`
+	Tag      string `yaml:"tag,omitempty" json:"tag,omitempty"`
+	Validate string `yaml:"validate,omitempty" json:"validate,omitempty"`
+	// JSONName is the JSON key of the field, the snake_case name by default
+	JSONName string `yaml:"json_name,omitempty" json:"json_name,omitempty"`
+
+	// Enum lists the allowed values of a field of type enum
+	Enum []string `yaml:"enum,omitempty" json:"enum,omitempty"`
+
+	// Nullable makes the field a pointer stored as a NULL-able column
+	Nullable bool `yaml:"nullable,omitempty" json:"nullable,omitempty"`
+	// Collection is slice or map, stored as a JSON serialized column
+	Collection string `yaml:"collection,omitempty" json:"collection,omitempty"`
+	// Precision and Scale size decimal columns
+	Precision int `yaml:"precision,omitempty" json:"precision,omitempty"`
+	Scale     int `yaml:"scale,omitempty" json:"scale,omitempty"`
+
+	// Default, Unique, Index, Column and Size configure the column
+	Default string `yaml:"default,omitempty" json:"default,omitempty"`
+	Unique  bool   `yaml:"unique,omitempty" json:"unique,omitempty"`
+	Index   bool   `yaml:"index,omitempty" json:"index,omitempty"`
+	Column  string `yaml:"column,omitempty" json:"column,omitempty"`
+	Size    int    `yaml:"size,omitempty" json:"size,omitempty"`
+	// ReadOnly fields are set by the server and never accepted in requests
+	ReadOnly bool `yaml:"read_only,omitempty" json:"read_only,omitempty"`
+	// WriteOnly fields are accepted in requests and never returned
+	WriteOnly bool `yaml:"write_only,omitempty" json:"write_only,omitempty"`
+}
+
+// FieldEnum is the field type of enum fields, which are generated as a
+// named string type with one constant per value
+const FieldEnum = "enum"
+
+// ParseEnumType parses the inline enum(a,b,c) field type syntax
+func ParseEnumType(s string) ([]string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, FieldEnum+"(") || !strings.HasSuffix(s, ")") {
+		return nil, false
+	}
+	var values []string
+	for _, value := range strings.Split(s[len(FieldEnum)+1:len(s)-1], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values, true
+}
+
+// CheckEnum reports a problem with the values of an enum field. Values
+// become Go constants and SQL literals, so they are restricted to letters,
+// digits, dashes and underscores.
+func CheckEnum(values []string) error {
+	if len(values) == 0 {
+		return fmt.Errorf("enum needs at least one value")
+	}
+	seen := map[string]bool{}
+	for _, value := range values {
+		for _, r := range value {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+				return fmt.Errorf("invalid enum value %q", value)
+			}
+		}
+		if seen[value] {
+			return fmt.Errorf("duplicate enum value %q", value)
+		}
+		seen[value] = true
+	}
+	return nil
+}
+
+// IsEnum reports whether the field is an enum
+func (f FieldConfig) IsEnum() bool {
+	return f.Type == FieldEnum
+}
+
+// ValidateTag returns the validation rules of the field including the
+// implicit oneof rule of enums. Optional enums and nullable fields skip
+// validation when no value is given.
+func (f FieldConfig) ValidateTag() string {
+	rules := f.Validate
+	if f.IsEnum() {
+		oneof := "oneof=" + strings.Join(f.Enum, " ")
+		if rules == "" {
+			rules = oneof
+		} else {
+			rules += "," + oneof
+		}
+	}
+
+	if f.Size > 0 && f.isString() && !strings.Contains(rules, "max=") {
+		if rules == "" {
+			rules = "max=" + strconv.Itoa(f.Size)
+		} else {
+			rules += ",max=" + strconv.Itoa(f.Size)
+		}
+	}
+
+	optional := f.IsEnum() || f.Nullable || f.Collection != ""
+	if rules == "" || !optional || strings.Contains(rules, "required") || strings.HasPrefix(rules, "omitempty") {
+		return rules
+	}
+	return "omitempty," + rules
+}
+
+// TableName returns the database table of the model
+func (m ModelConfig) TableName() string {
+	if m.Table != "" {
+		return m.Table
+	}
+	return inflect.Snake(inflect.Plural(m.Name))
+}
+
+// GoType returns the Go type of a field of the model
+func (m ModelConfig) GoType(field FieldConfig) string {
+	t := m.baseType(field)
+	switch {
+	case field.Collection == CollectionSlice:
+		return "[]" + t
+	case field.Collection == CollectionMap:
+		return "map[string]" + t
+	case field.Nullable:
+		return "*" + t
+	}
+	return t
+}
+
+// EnumType returns the name of the type generated for an enum field
+func (m ModelConfig) EnumType(field FieldConfig) string {
+	return m.Name + field.Name
+}
+
+// EnumConst returns the name of the constant generated for an enum value
+func (m ModelConfig) EnumConst(field FieldConfig, value string) string {
+	return m.EnumType(field) + inflect.Pascal(value)
+}
+
+// StructTag returns the struct tag of a field of the model, adding the
+// GORM options implied by its type and options. Write-only fields are
+// hidden from JSON.
+func (m ModelConfig) StructTag(field FieldConfig) string {
+	options := append(m.keyOptions(field), typeOptions(field)...)
+	options = append(options, optionTags(field)...)
+
+	if field.IsEnum() && len(field.Enum) > 0 {
+		column := field.ColumnName()
+		values := make([]string, len(field.Enum))
+		for i, value := range field.Enum {
+			values[i] = "'" + value + "'"
+		}
+		options = append(options, fmt.Sprintf("check:chk_%s_%s,%s IN (%s)", m.TableName(), column, column, strings.Join(values, ",")))
+		if !field.Nullable && field.Default == "" && !strings.Contains(field.Tag, "default:") {
+			options = append(options, "default:"+field.Enum[0])
+		}
+	}
+
+	tag := field.Tag
+	if field.WriteOnly {
+		tag = hideJSON(tag)
+	}
+	if len(options) == 0 {
+		return tag
+	}
+	return addGormOptions(tag, options)
+}
+
+// addGormOptions appends options to the gorm key of a backquoted struct
+// tag, adding the key if needed
+func addGormOptions(tag string, options []string) string {
+	tag = strings.Trim(strings.TrimSpace(tag), "`")
+	joined := strings.Join(options, ";")
+
+	if i := strings.Index(tag, `gorm:"`); i >= 0 {
+		start := i + len(`gorm:"`)
+		end := strings.Index(tag[start:], `"`)
+		if end >= 0 {
+			end += start
+			existing := tag[start:end]
+			if existing != "" {
+				joined = existing + ";" + joined
+			}
+			return "`" + tag[:start] + joined + tag[end:] + "`"
+		}
+	}
+
+	if tag == "" {
+		return "`gorm:\"" + joined + "\"`"
+	}
+	return "`gorm:\"" + joined + "\" " + tag + "`"
+}
+
+// Relation kinds supported by RelationConfig
+const (
+	RelationBelongsTo  = "belongs_to"
+	RelationHasOne     = "has_one"
+	RelationHasMany    = "has_many"
+	RelationManyToMany = "many2many"
+)
+
+// RelationKinds lists the supported relation kinds
+var RelationKinds = []string{RelationBelongsTo, RelationHasOne, RelationHasMany, RelationManyToMany}
+
+// ParseRelationKind returns the relation kind matching s, accepting
+// dashes and the many_to_many spelling
+func ParseRelationKind(s string) (string, bool) {
+	kind := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_")
+	if kind == "many_to_many" {
+		kind = RelationManyToMany
+	}
+	for _, known := range RelationKinds {
+		if kind == known {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// RelationConfig represents an association between two models
+type RelationConfig struct {
+	// Name is the association field, e.g. Customer or Tags
+	Name string `yaml:"name" json:"name"`
+	Kind string `yaml:"kind" json:"kind"`
+	// Model is the associated model, defaults to the singular of Name
+	Model string `yaml:"model,omitempty" json:"model,omitempty"`
+	// Table is the join table of a many2many relation
+	Table string `yaml:"table,omitempty" json:"table,omitempty"`
+	// JSONName is the JSON key of the association, the snake_case name by
+	// default
+	JSONName string `yaml:"json_name,omitempty" json:"json_name,omitempty"`
+	// OnDelete is the ON DELETE action of the foreign key of a belongs_to
+	// relation, such as CASCADE or SET NULL
+	OnDelete string `yaml:"on_delete,omitempty" json:"on_delete,omitempty"`
+}
+
+// OnDeleteActions lists the ON DELETE actions of foreign keys
+var OnDeleteActions = []string{"CASCADE", "SET NULL", "RESTRICT", "NO ACTION", "SET DEFAULT"}
+
+// ParseOnDelete returns the ON DELETE action matching s, ignoring case
+// and underscores
+func ParseOnDelete(s string) (string, bool) {
+	action := strings.ToUpper(strings.Join(strings.Fields(strings.ReplaceAll(s, "_", " ")), " "))
+	for _, known := range OnDeleteActions {
+		if action == known {
+			return action, true
+		}
+	}
+	return "", false
+}
+
+// JSONKey returns the JSON key of the association
+func (r RelationConfig) JSONKey() string {
+	if r.JSONName != "" {
+		return r.JSONName
+	}
+	return inflect.Snake(r.Name)
+}
+
+// Target returns the name of the associated model
+func (r RelationConfig) Target() string {
+	if r.Model != "" {
+		return r.Model
+	}
+	return inflect.Singular(r.Name)
+}
+
+// IsMany reports whether the relation holds a list of models
+func (r RelationConfig) IsMany() bool {
+	return r.Kind == RelationHasMany || r.Kind == RelationManyToMany
+}
+
+// ForeignKey returns the foreign key field a belongs_to relation adds to
+// its owner
+func (r RelationConfig) ForeignKey() string {
+	return r.Name + "ID"
+}
+
+// GeneratesForeignKey reports whether a relation adds its foreign key
+// field to the model. A belongs_to relation uses a field of the same name
+// the model declares instead, which carries the options of the column.
+func (m ModelConfig) GeneratesForeignKey(relation RelationConfig) bool {
+	return relation.Kind == RelationBelongsTo && !m.hasField(relation.ForeignKey())
+}
+
+// JoinTable returns the join table of a many2many relation declared on
+// the owner model
+func (r RelationConfig) JoinTable(owner string) string {
+	if r.Table != "" {
+		return r.Table
+	}
+	return inflect.Snake(owner) + "_" + inflect.Snake(r.Name)
+}
+
+// IDsField returns the request field carrying the IDs of a many2many
+// relation
+func (r RelationConfig) IDsField() string {
+	return inflect.Singular(r.Name) + "IDs"
 }
 
 // DefaultUserModel returns the default User model configuration
@@ -35,7 +432,7 @@ func DefaultUserModel() ModelConfig {
 			{Name: "ID", Type: "uint", Tag: "`gorm:\"primaryKey\" json:\"id\"`"},
 			{Name: "Name", Type: "string", Tag: "`gorm:\"not null\" json:\"name\"`", Validate: "required,min=2,max=100"},
 			{Name: "Email", Type: "string", Tag: "`gorm:\"unique;not null\" json:\"email\"`", Validate: "required,email"},
-			{Name: "Password", Type: "string", Tag: "`gorm:\"not null\" json:\"-\"`", Validate: "required,min=6"},
+			{Name: "Password", Type: "string", Tag: "`gorm:\"not null\" json:\"-\"`", Validate: "required,min=6", WriteOnly: true},
 			{Name: "IsActive", Type: "bool", Tag: "`gorm:\"default:true\" json:\"is_active\"`"},
 			{Name: "CreatedAt", Type: "time.Time", Tag: "`json:\"created_at\"`"},
 			{Name: "UpdatedAt", Type: "time.Time", Tag: "`json:\"updated_at\"`"},
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/diff"
+)
+
+// ConflictPolicy decides what happens when a file about to be generated
+// already exists with different content
+type ConflictPolicy int
+
+const (
+	// ConflictSkip keeps the existing file untouched
+	ConflictSkip ConflictPolicy = iota
+	// ConflictOverwrite replaces the existing file
+	ConflictOverwrite
+	// ConflictAsk prompts for every conflicting file
+	ConflictAsk
+)
+
+type conflictAction int
+
+const (
+	actionWrite conflictAction = iota
+	actionSkip
+	actionWriteNew
+	actionAsk
+)
+
+// resolveConflict decides how to handle an existing file with different
+// content. Dry runs never prompt, they only report that a prompt would
+// be shown.
+func (g *Generator) resolveConflict(filePath string, old, content []byte) conflictAction {
+	switch g.opts.Conflict {
+	case ConflictOverwrite:
+		return actionWrite
+	case ConflictAsk:
+		if g.opts.DryRun {
+			return actionAsk
+		}
+		return g.askConflict(filePath, old, content)
+	default:
+		return actionSkip
+	}
+}
+
+// askConflict prompts until a decision is made for the file. Answering
+// "all" switches the generator to overwrite the remaining conflicts.
+func (g *Generator) askConflict(filePath string, old, content []byte) conflictAction {
+	for {
+		answer := g.opts.Prompt(fmt.Sprintf("⚠️  %s already exists. [o]verwrite, [s]kip, show [d]iff, write .[n]ew, overwrite [a]ll (default: skip): ", filePath))
+		switch strings.ToLower(answer) {
+		case "o", "overwrite":
+			return actionWrite
+		case "", "s", "skip":
+			return actionSkip
+		case "n", "new":
+			return actionWriteNew
+		case "a", "all":
+			g.opts.Conflict = ConflictOverwrite
+			return actionWrite
+		case "d", "diff":
+			path := filepath.ToSlash(filePath)
+			fmt.Fprint(g.opts.Out, diff.Unified("a/"+path, "b/"+path, string(old), string(content)))
+		default:
+			fmt.Fprintf(g.opts.Out, "Unknown answer %q\n", answer)
+		}
+	}
+}
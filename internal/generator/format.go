@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SyntaxError reports generated Go code that does not parse, which is
+// always a bug in the template that produced it
+type SyntaxError struct {
+	Template string
+	Line     int
+	Column   int
+	Msg      string
+	Source   string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("template %s produced invalid Go at line %d:%d: %s\n\t%d | %s",
+		e.Template, e.Line, e.Column, e.Msg, e.Line, e.Source)
+}
+
+// formatSource removes unused imports from generated Go source and formats
+// it with gofmt
+func formatSource(name string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+	if err != nil {
+		return nil, syntaxError(name, src, err)
+	}
+
+	pruneImports(fset, file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func syntaxError(name string, src []byte, err error) error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok || len(list) == 0 {
+		return fmt.Errorf("template %s produced invalid Go: %w", name, err)
+	}
+
+	pos := list[0].Pos
+	lines := strings.Split(string(src), "\n")
+	var source string
+	if pos.Line > 0 && pos.Line <= len(lines) {
+		source = lines[pos.Line-1]
+	}
+	return &SyntaxError{
+		Template: name,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Msg:      list[0].Msg,
+		Source:   source,
+	}
+}
+
+// pruneImports deletes imports whose package is never the qualifier of a
+// selector along with their comments, closing the lines they leave in
+// their group
+func pruneImports(fset *token.FileSet, file *ast.File) {
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+	keep := func(imp *ast.ImportSpec) bool {
+		name := importName(imp)
+		return name == "_" || name == "." || used[name]
+	}
+
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+
+		// Lines are merged bottom up so the line numbers of the specs
+		// above stay valid
+		removed := map[ast.Spec]bool{}
+		for i := len(gen.Specs) - 1; i >= 0; i-- {
+			if keep(gen.Specs[i].(*ast.ImportSpec)) {
+				continue
+			}
+			removed[gen.Specs[i]] = true
+			if gen.Rparen.IsValid() {
+				closeLine(fset, gen, i)
+			}
+		}
+
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			if !removed[spec] {
+				specs = append(specs, spec)
+			}
+		}
+		gen.Specs = specs
+		if len(specs) > 0 {
+			decls = append(decls, gen)
+		}
+	}
+	file.Decls = decls
+
+	imports := file.Imports[:0]
+	dropped := map[*ast.CommentGroup]bool{}
+	for _, imp := range file.Imports {
+		if keep(imp) {
+			imports = append(imports, imp)
+			continue
+		}
+		dropped[imp.Doc], dropped[imp.Comment] = true, true
+	}
+	file.Imports = imports
+
+	comments := file.Comments[:0]
+	for _, group := range file.Comments {
+		if !dropped[group] {
+			comments = append(comments, group)
+		}
+	}
+	file.Comments = comments
+}
+
+// closeLine merges the lines of the i-th import spec of gen, including its
+// doc comment, into the next one when they directly follow the line above,
+// so removing the spec does not leave a blank line inside its group. A
+// spec after a blank line leaves a gap the printer collapses with the one
+// separating the groups.
+func closeLine(fset *token.FileSet, gen *ast.GenDecl, i int) {
+	imp := gen.Specs[i].(*ast.ImportSpec)
+	first := fset.Position(imp.Pos()).Line
+	if imp.Doc != nil {
+		first = fset.Position(imp.Doc.Pos()).Line
+	}
+	last := fset.Position(imp.End()).Line
+	above := fset.Position(gen.Lparen).Line
+	if i > 0 {
+		above = fset.Position(gen.Specs[i-1].End()).Line
+	}
+	if first-above != 1 {
+		return
+	}
+	for line := first; line <= last && first < fset.Position(gen.Rparen).Line; line++ {
+		fset.File(gen.Rparen).MergeLine(first)
+	}
+}
+
+var versionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// importName returns the name an import is referenced by, guessing the
+// package name from its path the same way goimports does
+func importName(imp *ast.ImportSpec) string {
+	if imp.Name != nil {
+		return imp.Name.Name
+	}
+
+	importPath, err := strconv.Unquote(imp.Path.Value)
+	if err != nil {
+		return ""
+	}
+
+	name := path.Base(importPath)
+	if versionSuffix.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	name = strings.TrimSuffix(name, "-go")
+	return strings.ReplaceAll(name, "-", "")
+}
@@ -1,34 +1,161 @@
 package generator
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
 	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/diff"
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+	"github.com/erwinhermantodev/hexa-go/internal/prompts"
+	"github.com/erwinhermantodev/hexa-go/internal/templates"
 )
 
+// Options configures a Generator
+type Options struct {
+	// DryRun renders every file in memory and prints the planned changes
+	// as a unified diff instead of writing them to disk
+	DryRun bool
+
+	// Conflict decides what happens to existing files whose content
+	// differs from the generated one, defaults to ConflictSkip
+	Conflict ConflictPolicy
+
+	// Out receives dry-run previews and conflict notices, defaults to
+	// os.Stdout
+	Out io.Writer
+
+	// Prompt reads an answer for interactive conflict resolution, defaults
+	// to prompts.PromptForInput
+	Prompt func(message string) string
+}
+
 // Generator handles code generation
-type Generator struct{}
+type Generator struct {
+	opts Options
+
+	// depth tracks nested public operations. Files and directories are
+	// staged in memory and only committed to disk, or previewed in dry-run
+	// mode, when the outermost operation finishes without error.
+	depth  int
+	dirs   []string
+	staged []stagedFile
+}
+
+// stagedFile is a rendered file waiting to be committed
+type stagedFile struct {
+	path    string
+	content []byte
+	old     []byte
+	exists  bool
+	action  conflictAction
+}
 
 // New creates a new Generator instance
 func New() *Generator {
-	return &Generator{}
+	return NewWithOptions(Options{})
+}
+
+// NewWithOptions creates a new Generator instance with the given options
+func NewWithOptions(opts Options) *Generator {
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	if opts.Prompt == nil {
+		opts.Prompt = prompts.PromptForInput
+	}
+	return &Generator{opts: opts}
 }
 
 // CreateFileFromTemplate creates a file from a template
 func (g *Generator) CreateFileFromTemplate(filePath, tmplContent string, data interface{}) error {
-	// Ensure directory exists
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	return g.render(filepath.ToSlash(filePath), filePath, tmplContent, data)
+}
+
+// createFile creates a file from the named template, honouring overrides
+// in the project and user template directories
+func (g *Generator) createFile(projectConfig config.ProjectConfig, name, filePath string, data interface{}, managed bool) error {
+	content, err := renderFile(projectConfig, name, filePath, data)
+	if err != nil {
+		return err
+	}
+	if managed {
+		return g.writeManagedFile(filePath, content)
+	}
+	return g.writeFile(filePath, content)
+}
+
+// renderFile renders the named template for the file at filePath,
+// honouring overrides in the project and user template directories
+func renderFile(projectConfig config.ProjectConfig, name, filePath string, data interface{}) ([]byte, error) {
+	tmplContent, source, err := templates.Lookup(name, templates.SearchDirs(projectConfig.OutputDir()))
+	if err != nil {
+		return nil, err
+	}
+	if source != templates.BuiltinSource {
+		name = fmt.Sprintf("%s (%s)", name, source)
+	}
+	return renderSource(name, filePath, tmplContent, data)
+}
+
+// renderScope renders every registered template of a scope whose
+// condition holds for the given model
+func (g *Generator) renderScope(projectConfig config.ProjectConfig, scope templates.Scope, model config.ModelConfig, data interface{}) error {
+	for _, tmpl := range templates.ForScope(scope) {
+		if tmpl.When != nil && !tmpl.When(projectConfig, model) {
+			continue
+		}
+		if err := g.renderTemplate(projectConfig, tmpl, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderTemplate renders a registered template to its output path
+func (g *Generator) renderTemplate(projectConfig config.ProjectConfig, tmpl templates.Template, data interface{}) error {
+	filePath, err := outputPath(projectConfig, tmpl, data)
+	if err != nil {
 		return err
 	}
+	return g.createFile(projectConfig, tmpl.Name, filePath, data, tmpl.Managed)
+}
+
+// outputPath returns the path a registered template is rendered to
+func outputPath(projectConfig config.ProjectConfig, tmpl templates.Template, data interface{}) (string, error) {
+	var relPath strings.Builder
+	pathTmpl, err := template.New(tmpl.Name + " path").Funcs(funcMap()).Parse(tmpl.Path)
+	if err != nil {
+		return "", err
+	}
+	if err := pathTmpl.Execute(&relPath, data); err != nil {
+		return "", err
+	}
+	return filepath.Join(projectConfig.OutputDir(), relPath.String()), nil
+}
 
-	tmpl, err := template.New("file").Funcs(template.FuncMap{
-		"ToLower": strings.ToLower,
-		"ToUpper": strings.ToUpper,
-		"Title":   strings.Title,
+// funcMap returns the helper functions available to all templates
+func funcMap() template.FuncMap {
+	return template.FuncMap{
+		"ToLower":   strings.ToLower,
+		"ToUpper":   strings.ToUpper,
+		"Title":     strings.Title,
+		"join":      strings.Join,
+		"hasPrefix": strings.HasPrefix,
+		"plural":    inflect.Plural,
+		"singular":  inflect.Singular,
+		"snake":     inflect.Snake,
+		"kebab":     inflect.Kebab,
+		"camel":     inflect.Camel,
+		"pascal":    inflect.Pascal,
+		"humanize":  inflect.Humanize,
+		"bitSize":   bitSize,
 		"contains": func(fields []config.FieldConfig, fieldType string) bool {
 			for _, field := range fields {
 				if strings.Contains(field.Type, fieldType) {
@@ -37,16 +164,165 @@ func (g *Generator) CreateFileFromTemplate(filePath, tmplContent string, data in
 			}
 			return false
 		},
-	}).Parse(tmplContent)
+	}
+}
+
+// bitSize returns the bitSize argument strconv needs to parse an integer
+// type without overflowing it
+func bitSize(goType string) string {
+	switch goType {
+	case "int", "uint":
+		return "strconv.IntSize"
+	case "int8", "uint8":
+		return "8"
+	case "int16", "uint16":
+		return "16"
+	case "int32", "uint32":
+		return "32"
+	}
+	return "64"
+}
+
+// render executes a template and stages the result, formatting Go files
+func (g *Generator) render(name, filePath, tmplContent string, data interface{}) error {
+	content, err := renderSource(name, filePath, tmplContent, data)
+	if err != nil {
+		return err
+	}
+	return g.writeFile(filePath, content)
+}
+
+// renderSource executes a template for the file at filePath, formatting
+// Go files
+func renderSource(name, filePath, tmplContent string, data interface{}) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(funcMap()).Parse(tmplContent)
 	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	content := buf.Bytes()
+	if filepath.Ext(filePath) == ".go" {
+		return formatSource(name, content)
+	}
+	return content, nil
+}
+
+// run executes a public generator operation. Nested operations share the
+// outermost one, which commits everything that was staged, or prints the
+// preview in dry-run mode. Nothing is written if any step fails.
+func (g *Generator) run(fn func() error) error {
+	g.depth++
+	err := fn()
+	g.depth--
+	if g.depth > 0 {
 		return err
 	}
 
-	file, err := os.Create(filePath)
+	defer func() {
+		g.dirs = nil
+		g.staged = nil
+	}()
+
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	if g.opts.DryRun {
+		g.printPlan()
+		return nil
+	}
+	return g.commit()
+}
 
-	return tmpl.Execute(file, data)
+// mkdirAll stages the creation of a directory
+func (g *Generator) mkdirAll(dir string) {
+	g.dirs = append(g.dirs, dir)
+}
+
+// writeFile stages generated content for writing. Existing files are
+// handled according to the conflict policy.
+func (g *Generator) writeFile(filePath string, content []byte) error {
+	return g.putFile(filePath, content, false)
+}
+
+// writeManagedFile stages a file owned by the generator itself, such as the
+// manifest or the wiring of a project, which is always replaced
+func (g *Generator) writeManagedFile(filePath string, content []byte) error {
+	return g.putFile(filePath, content, true)
+}
+
+func (g *Generator) putFile(filePath string, content []byte, managed bool) error {
+	old, err := os.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	exists := err == nil
+
+	action := actionWrite
+	if exists && !managed && !bytes.Equal(old, content) {
+		action = g.resolveConflict(filePath, old, content)
+	}
+
+	file := stagedFile{
+		path:    filePath,
+		content: content,
+		old:     old,
+		exists:  exists,
+		action:  action,
+	}
+	for i := range g.staged {
+		if g.staged[i].path == filePath {
+			g.staged[i] = file
+			return nil
+		}
+	}
+	g.staged = append(g.staged, file)
+	return nil
+}
+
+// stagedAction returns how the staged file at filePath will be committed
+func (g *Generator) stagedAction(filePath string) conflictAction {
+	for _, file := range g.staged {
+		if file.path == filePath {
+			return file.action
+		}
+	}
+	return actionWrite
+}
+
+// printPlan prints the list of planned files followed by their diffs
+func (g *Generator) printPlan() {
+	out := g.opts.Out
+	sort.SliceStable(g.staged, func(i, j int) bool { return g.staged[i].path < g.staged[j].path })
+
+	fmt.Fprintln(out, "🔍 Dry run, no files were written:")
+	for _, file := range g.staged {
+		switch {
+		case !file.exists:
+			fmt.Fprintf(out, "  + %s\n", file.path)
+		case bytes.Equal(file.old, file.content):
+			fmt.Fprintf(out, "  = %s (unchanged)\n", file.path)
+		case file.action == actionSkip:
+			fmt.Fprintf(out, "  ! %s (exists, will be skipped; use --force to overwrite)\n", file.path)
+		case file.action == actionAsk:
+			fmt.Fprintf(out, "  ? %s (exists, will ask before overwriting)\n", file.path)
+		default:
+			fmt.Fprintf(out, "  ~ %s\n", file.path)
+		}
+	}
+
+	for _, file := range g.staged {
+		oldName := "/dev/null"
+		if file.exists {
+			oldName = "a/" + filepath.ToSlash(file.path)
+		}
+		if d := diff.Unified(oldName, "b/"+filepath.ToSlash(file.path), string(file.old), string(file.content)); d != "" {
+			fmt.Fprintln(out)
+			fmt.Fprint(out, d)
+		}
+	}
 }
@@ -1,23 +1,52 @@
 package generator
 
 import (
-	"path/filepath"
-	"strings"
-
 	"github.com/erwinhermantodev/hexa-go/internal/config"
 	"github.com/erwinhermantodev/hexa-go/internal/templates"
 )
 
 // GenerateHandlerFile generates a standalone handler file
 func (g *Generator) GenerateHandlerFile(projectConfig config.ProjectConfig, handlerName string) error {
-	baseDir := projectConfig.Name
-	if baseDir == "" {
-		baseDir = "."
-	}
+	return g.run(func() error {
+		return g.renderScope(projectConfig, templates.ScopeHandler, config.ModelConfig{}, map[string]interface{}{
+			"Config":      projectConfig,
+			"HandlerName": handlerName,
+		})
+	})
+}
+
+// AddHandler generates a standalone handler that was appended to the project
+// configuration and updates the wiring and the manifest
+func (g *Generator) AddHandler(projectConfig config.ProjectConfig, handlerName string) error {
+	return g.run(func() error {
+		if err := g.GenerateHandlerFile(projectConfig, handlerName); err != nil {
+			return err
+		}
+		if err := g.GenerateWiring(projectConfig); err != nil {
+			return err
+		}
+		return g.SaveManifest(projectConfig)
+	})
+}
 
-	handlerPath := filepath.Join(baseDir, "transport/http/handler", strings.ToLower(handlerName)+"_handler.go")
-	return g.CreateFileFromTemplate(handlerPath, templates.CustomHandlerTemplate, map[string]interface{}{
-		"Config":      projectConfig,
-		"HandlerName": handlerName,
+// AddAPI generates the models and standalone handlers of an imported API
+// contract that were appended to the project configuration and updates the
+// wiring and the manifest once
+func (g *Generator) AddAPI(projectConfig config.ProjectConfig, models []config.ModelConfig, handlers []string) error {
+	return g.run(func() error {
+		for _, model := range models {
+			if err := g.GenerateModelFiles(projectConfig, model); err != nil {
+				return err
+			}
+		}
+		for _, handlerName := range handlers {
+			if err := g.GenerateHandlerFile(projectConfig, handlerName); err != nil {
+				return err
+			}
+		}
+		if err := g.GenerateWiring(projectConfig); err != nil {
+			return err
+		}
+		return g.SaveManifest(projectConfig)
 	})
 }
@@ -0,0 +1,17 @@
+package generator
+
+import (
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/manifest"
+)
+
+// SaveManifest writes the project manifest to the project directory
+func (g *Generator) SaveManifest(projectConfig config.ProjectConfig) error {
+	return g.run(func() error {
+		data, err := manifest.Marshal(projectConfig)
+		if err != nil {
+			return err
+		}
+		return g.writeManagedFile(manifest.Path(projectConfig.OutputDir()), data)
+	})
+}
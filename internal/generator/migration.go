@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/migration"
+)
+
+// migrationsDir is the directory of the golang-migrate migrations of a
+// generated project
+const migrationsDir = "migrations"
+
+// migrationVersionLayout is the time layout of migration versions
+const migrationVersionLayout = "20060102150405"
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// GenerateMigration writes the migration creating the tables of a model.
+// Migrations are history: a table that already has a create migration
+// keeps it, later changes get migrations of their own.
+func (g *Generator) GenerateMigration(projectConfig config.ProjectConfig, model config.ModelConfig) error {
+	return g.run(func() error {
+		m, ok := migration.Create(migration.DialectOf(projectConfig), projectConfig, model.Name)
+		if !ok || g.hasMigration(projectConfig, m.Name) {
+			return nil
+		}
+		return g.writeMigration(projectConfig, m)
+	})
+}
+
+// UpdateModel regenerates the files of a model changed in the project
+// configuration, writes the migration named change altering the tables
+// from the previous configuration and updates the manifest. Nothing is
+// written if a model file edited by hand would be kept.
+func (g *Generator) UpdateModel(previous, projectConfig config.ProjectConfig, model config.ModelConfig, change string) error {
+	return g.run(func() error {
+		// Tables of projects generated before migrations existed get their
+		// create migrations from the previous configuration first
+		for _, existing := range previous.Models {
+			if err := g.GenerateMigration(previous, existing); err != nil {
+				return err
+			}
+		}
+		if err := g.updateModelFiles(previous, projectConfig, model); err != nil {
+			return err
+		}
+		if m, ok := migration.Alter(migration.DialectOf(projectConfig), previous, projectConfig, change); ok {
+			if err := g.writeMigration(projectConfig, m); err != nil {
+				return err
+			}
+		}
+		return g.SaveManifest(projectConfig)
+	})
+}
+
+// writeMigration stages the up and down files of a migration under a new
+// version
+func (g *Generator) writeMigration(projectConfig config.ProjectConfig, m migration.Migration) error {
+	dir := filepath.Join(projectConfig.OutputDir(), migrationsDir)
+	g.mkdirAll(dir)
+
+	base := filepath.Join(dir, g.nextMigrationVersion(dir)+"_"+m.Name)
+	if err := g.writeFile(base+".up.sql", []byte(m.Up)); err != nil {
+		return err
+	}
+	return g.writeFile(base+".down.sql", []byte(m.Down))
+}
+
+// hasMigration reports whether a migration with the given name exists or
+// is staged
+func (g *Generator) hasMigration(projectConfig config.ProjectConfig, name string) bool {
+	for _, file := range g.migrationFiles(filepath.Join(projectConfig.OutputDir(), migrationsDir)) {
+		if match := migrationFile.FindStringSubmatch(file); match[2] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// nextMigrationVersion returns a timestamp version later than every
+// existing or staged migration, a second after the latest one if the
+// current time is not
+func (g *Generator) nextMigrationVersion(dir string) string {
+	version := time.Now().UTC().Truncate(time.Second)
+	for _, file := range g.migrationFiles(dir) {
+		existing, err := time.Parse(migrationVersionLayout, migrationFile.FindStringSubmatch(file)[1])
+		if err == nil && !existing.Before(version) {
+			version = existing.Add(time.Second)
+		}
+	}
+	return version.Format(migrationVersionLayout)
+}
+
+// migrationFiles returns the names of the migration files in dir,
+// including staged ones
+func (g *Generator) migrationFiles(dir string) []string {
+	var files []string
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if migrationFile.MatchString(entry.Name()) {
+			files = append(files, entry.Name())
+		}
+	}
+	for _, file := range g.staged {
+		if filepath.Dir(file.path) == dir && migrationFile.MatchString(filepath.Base(file.path)) {
+			files = append(files, filepath.Base(file.path))
+		}
+	}
+	return files
+}
@@ -1,61 +1,152 @@
 package generator
 
 import (
-	"path/filepath"
+	"bytes"
+	"fmt"
+	"os"
 	"strings"
 
 	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/migration"
 	"github.com/erwinhermantodev/hexa-go/internal/templates"
 )
 
-// GenerateModelFiles generates all files for a model
+// GenerateModelFiles generates all files for a model and the migration
+// creating its tables
 func (g *Generator) GenerateModelFiles(projectConfig config.ProjectConfig, model config.ModelConfig) error {
-	baseDir := projectConfig.Name
-	if baseDir == "" {
-		baseDir = "."
-	}
+	return g.run(func() error {
+		if err := g.renderModel(projectConfig, model); err != nil {
+			return err
+		}
+		return g.GenerateMigration(projectConfig, model)
+	})
+}
 
-	// Generate model file
-	modelPath := filepath.Join(baseDir, "model", strings.ToLower(model.Name)+".go")
-	if err := g.CreateFileFromTemplate(modelPath, templates.DynamicModelTemplate, map[string]interface{}{
+// renderModel renders the model scope templates of a model
+func (g *Generator) renderModel(projectConfig config.ProjectConfig, model config.ModelConfig) error {
+	return g.renderScope(projectConfig, templates.ScopeModel, model, map[string]interface{}{
 		"Config": projectConfig,
 		"Model":  model,
-	}); err != nil {
-		return err
-	}
+	})
+}
 
-	// Generate repository if needed
-	if model.HasRepo {
-		repoPath := filepath.Join(baseDir, "repository", strings.ToLower(model.Name)+".go")
-		if err := g.CreateFileFromTemplate(repoPath, templates.DynamicRepositoryTemplate, map[string]interface{}{
-			"Config": projectConfig,
-			"Model":  model,
-		}); err != nil {
+// AddModel generates the files for a model that was appended to the
+// project configuration and updates the wiring and the manifest
+func (g *Generator) AddModel(projectConfig config.ProjectConfig, model config.ModelConfig) error {
+	return g.run(func() error {
+		if err := g.GenerateModelFiles(projectConfig, model); err != nil {
 			return err
 		}
-	}
+		if err := g.GenerateWiring(projectConfig); err != nil {
+			return err
+		}
+		return g.SaveManifest(projectConfig)
+	})
+}
 
-	// Generate service if needed
-	if model.HasService {
-		servicePath := filepath.Join(baseDir, "service", strings.ToLower(model.Name)+".go")
-		if err := g.CreateFileFromTemplate(servicePath, templates.DynamicServiceTemplate, map[string]interface{}{
-			"Config": projectConfig,
-			"Model":  model,
-		}); err != nil {
+// AddModels generates the files for several models that were appended to
+// the project configuration and updates the wiring and the manifest once
+func (g *Generator) AddModels(projectConfig config.ProjectConfig, models []config.ModelConfig) error {
+	return g.run(func() error {
+		for _, model := range models {
+			if err := g.GenerateModelFiles(projectConfig, model); err != nil {
+				return err
+			}
+		}
+		if err := g.GenerateWiring(projectConfig); err != nil {
 			return err
 		}
-	}
+		return g.SaveManifest(projectConfig)
+	})
+}
 
-	// Generate handler if needed
-	if model.HasHandler {
-		handlerPath := filepath.Join(baseDir, "transport/http/handler", strings.ToLower(model.Name)+"_handler.go")
-		if err := g.CreateFileFromTemplate(handlerPath, templates.DynamicHandlerTemplate, map[string]interface{}{
-			"Config": projectConfig,
-			"Model":  model,
-		}); err != nil {
+// ImportModels generates the files for models imported from the tables of
+// an existing database and updates the wiring and the manifest once. The
+// tables exist, so they get baseline migrations instead of create ones.
+func (g *Generator) ImportModels(projectConfig config.ProjectConfig, models []config.ModelConfig) error {
+	return g.run(func() error {
+		for _, model := range models {
+			if err := g.renderModel(projectConfig, model); err != nil {
+				return err
+			}
+			m, ok := migration.Baseline(projectConfig, model.Name)
+			if !ok || g.hasMigration(projectConfig, m.Name) {
+				continue
+			}
+			if err := g.writeMigration(projectConfig, m); err != nil {
+				return err
+			}
+		}
+		if err := g.GenerateWiring(projectConfig); err != nil {
 			return err
 		}
-	}
+		return g.SaveManifest(projectConfig)
+	})
+}
+
+// updateModelFiles regenerates the files of a model changed from the
+// previous configuration. Files still matching what the previous
+// configuration renders were not edited by hand and are replaced, edited
+// ones follow the conflict policy. It fails if a file would be kept, so
+// that the model never disagrees with its migrations.
+func (g *Generator) updateModelFiles(previous, projectConfig config.ProjectConfig, model config.ModelConfig) error {
+	previousModel, _ := previous.FindModel(model.Name)
+	data := map[string]interface{}{"Config": projectConfig, "Model": model}
+	previousData := map[string]interface{}{"Config": previous, "Model": previousModel}
 
+	var kept []string
+	for _, tmpl := range templates.ForScope(templates.ScopeModel) {
+		if tmpl.When != nil && !tmpl.When(projectConfig, model) {
+			continue
+		}
+		filePath, err := outputPath(projectConfig, tmpl, data)
+		if err != nil {
+			return err
+		}
+		content, err := renderFile(projectConfig, tmpl.Name, filePath, data)
+		if err != nil {
+			return err
+		}
+
+		generated, err := g.isGenerated(previous, previousModel, tmpl, filePath, previousData)
+		if err != nil {
+			return err
+		}
+		if generated {
+			if err := g.writeManagedFile(filePath, content); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := g.writeFile(filePath, content); err != nil {
+			return err
+		}
+		if action := g.stagedAction(filePath); action != actionWrite && action != actionAsk {
+			kept = append(kept, filePath)
+		}
+	}
+	if len(kept) > 0 {
+		return fmt.Errorf("%s edited by hand and would keep the old fields, nothing was changed (use --force to overwrite)", strings.Join(kept, ", "))
+	}
 	return nil
 }
+
+// isGenerated reports whether the output of a model template is missing or
+// unchanged since it was rendered for the previous configuration
+func (g *Generator) isGenerated(previous config.ProjectConfig, previousModel config.ModelConfig, tmpl templates.Template, filePath string, previousData interface{}) (bool, error) {
+	existing, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if tmpl.When != nil && !tmpl.When(previous, previousModel) {
+		return false, nil
+	}
+	rendered, err := renderFile(previous, tmpl.Name, filePath, previousData)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(existing, rendered), nil
+}
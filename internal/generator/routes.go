@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/templates"
+)
+
+// setupRoutes is the function of the routes file registering the routes
+// of every handler
+const setupRoutes = "SetupRoutes"
+
+// updateRoutes adds the routes of new handlers to the routes file of a
+// project. The file is rendered for the project and the functions the
+// existing file lacks are appended, together with their calls in
+// SetupRoutes and the imports they need. Everything else is kept as
+// written, so a call removed by hand stays removed while its function is
+// kept.
+func (g *Generator) updateRoutes(projectConfig config.ProjectConfig) error {
+	tmpl, _ := templates.Find("routes")
+	filePath := filepath.Join(projectConfig.OutputDir(), tmpl.Path)
+	existing, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return g.renderTemplate(projectConfig, tmpl, projectConfig)
+	}
+	if err != nil {
+		return err
+	}
+
+	tmplContent, source, err := templates.Lookup(tmpl.Name, templates.SearchDirs(projectConfig.OutputDir()))
+	if err != nil {
+		return err
+	}
+	name := tmpl.Name
+	if source != templates.BuiltinSource {
+		name = fmt.Sprintf("%s (%s)", name, source)
+	}
+	rendered, err := renderSource(name, filePath, tmplContent, projectConfig)
+	if err != nil {
+		return err
+	}
+
+	merged, err := mergeRoutes(filePath, existing, rendered)
+	if err != nil {
+		fmt.Fprintf(g.opts.Out, "⚠️  %v, register the routes of new handlers by hand\n", err)
+		return nil
+	}
+	return g.writeManagedFile(filePath, merged)
+}
+
+// edit inserts text at an offset of a source file
+type edit struct {
+	offset int
+	text   string
+}
+
+// mergeRoutes adds the functions of rendered that existing lacks to
+// existing, calling them from its SetupRoutes like rendered does
+func mergeRoutes(name string, existing, rendered []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	dst, err := parser.ParseFile(fset, name, existing, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	src, err := parser.ParseFile(fset, name, rendered, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	dstSetup, srcSetup := findFunc(dst, setupRoutes), findFunc(src, setupRoutes)
+	if dstSetup == nil || srcSetup == nil {
+		return nil, fmt.Errorf("%s has no %s function", name, setupRoutes)
+	}
+	params := map[string]bool{}
+	for _, field := range dstSetup.Type.Params.List {
+		for _, ident := range field.Names {
+			params[ident.Name] = true
+		}
+	}
+	for _, field := range srcSetup.Type.Params.List {
+		for _, ident := range field.Names {
+			if !params[ident.Name] {
+				return nil, fmt.Errorf("%s of %s has no %s parameter", setupRoutes, name, ident.Name)
+			}
+		}
+	}
+
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	text := func(node ast.Node) string { return string(rendered[offset(node.Pos()):offset(node.End())]) }
+
+	var edits []edit
+	added := map[string]bool{}
+	for _, decl := range src.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || findFunc(dst, fn.Name.Name) != nil {
+			continue
+		}
+		start := fn.Pos()
+		if fn.Doc != nil {
+			start = fn.Doc.Pos()
+		}
+		added[fn.Name.Name] = true
+		edits = append(edits, edit{len(existing), "\n" + string(rendered[offset(start):offset(fn.End())]) + "\n"})
+	}
+	if len(added) == 0 {
+		return existing, nil
+	}
+
+	for _, stmt := range srcSetup.Body.List {
+		expr, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		if call, ok := expr.X.(*ast.CallExpr); ok {
+			if fn, ok := call.Fun.(*ast.Ident); ok && added[fn.Name] {
+				edits = append(edits, edit{offset(dstSetup.Body.Rbrace), "\t" + text(stmt) + "\n"})
+			}
+		}
+	}
+
+	imported := map[string]bool{}
+	for _, spec := range dst.Imports {
+		imported[spec.Path.Value] = true
+	}
+	for _, spec := range src.Imports {
+		if !imported[spec.Path.Value] {
+			edits = append(edits, importEdit(dst, offset, text(spec)))
+		}
+	}
+
+	// Text inserted at the same offset keeps the order of its edits
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].offset < edits[j].offset })
+	var merged strings.Builder
+	last := 0
+	for _, e := range edits {
+		merged.Write(existing[last:e.offset])
+		merged.WriteString(e.text)
+		last = e.offset
+	}
+	merged.Write(existing[last:])
+	return formatSource(name, []byte(merged.String()))
+}
+
+// importEdit returns the edit adding an import spec to a file
+func importEdit(file *ast.File, offset func(token.Pos) int, spec string) edit {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			return edit{offset(gen.Rparen), "\t" + spec + "\n"}
+		}
+		return edit{offset(gen.End()), "\nimport " + spec}
+	}
+	return edit{offset(file.Name.End()), "\n\nimport " + spec}
+}
+
+func findFunc(file *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return fn
+		}
+	}
+	return nil
+}
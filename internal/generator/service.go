@@ -1,23 +1,30 @@
 package generator
 
 import (
-	"path/filepath"
-	"strings"
-
 	"github.com/erwinhermantodev/hexa-go/internal/config"
 	"github.com/erwinhermantodev/hexa-go/internal/templates"
 )
 
 // GenerateServiceFile generates a standalone service file
 func (g *Generator) GenerateServiceFile(projectConfig config.ProjectConfig, serviceName string) error {
-	baseDir := projectConfig.Name
-	if baseDir == "" {
-		baseDir = "."
-	}
+	return g.run(func() error {
+		return g.renderScope(projectConfig, templates.ScopeService, config.ModelConfig{}, map[string]interface{}{
+			"Config":      projectConfig,
+			"ServiceName": serviceName,
+		})
+	})
+}
 
-	servicePath := filepath.Join(baseDir, "service", strings.ToLower(serviceName)+".go")
-	return g.CreateFileFromTemplate(servicePath, templates.CustomServiceTemplate, map[string]interface{}{
-		"Config":      projectConfig,
-		"ServiceName": serviceName,
+// AddService generates a standalone service that was appended to the project
+// configuration and updates the wiring and the manifest
+func (g *Generator) AddService(projectConfig config.ProjectConfig, serviceName string) error {
+	return g.run(func() error {
+		if err := g.GenerateServiceFile(projectConfig, serviceName); err != nil {
+			return err
+		}
+		if err := g.GenerateWiring(projectConfig); err != nil {
+			return err
+		}
+		return g.SaveManifest(projectConfig)
 	})
 }
@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// journal records every change made while committing staged files so
+// that a failed commit can be rolled back
+type journal struct {
+	dirs    []string
+	created []string
+	backups []backup
+}
+
+type backup struct {
+	path    string
+	content []byte
+}
+
+// commit writes all staged directories and files, rolling back every
+// change made so far if one of them fails
+func (g *Generator) commit() error {
+	var j journal
+
+	for _, dir := range g.dirs {
+		if err := j.mkdirAll(dir); err != nil {
+			return j.abort(err)
+		}
+	}
+
+	for _, file := range g.staged {
+		filePath := file.path
+		switch file.action {
+		case actionSkip:
+			fmt.Fprintf(g.opts.Out, "⏭️  Skipped existing %s (use --force to overwrite)\n", filePath)
+			continue
+		case actionWriteNew:
+			filePath += ".new"
+			fmt.Fprintf(g.opts.Out, "📄 Wrote %s alongside the existing file\n", filePath)
+		}
+
+		if err := j.writeFile(filePath, file.content); err != nil {
+			return j.abort(err)
+		}
+	}
+
+	return nil
+}
+
+// mkdirAll creates a directory and its missing parents, recording each
+// directory it created
+func (j *journal) mkdirAll(dir string) error {
+	var missing []string
+	for p := filepath.Clean(dir); ; p = filepath.Dir(p) {
+		if _, err := os.Stat(p); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		missing = append(missing, p)
+		if p == filepath.Dir(p) {
+			break
+		}
+	}
+
+	for i := len(missing) - 1; i >= 0; i-- {
+		if err := os.Mkdir(missing[i], 0755); err != nil && !os.IsExist(err) {
+			return err
+		}
+		j.dirs = append(j.dirs, missing[i])
+	}
+	return nil
+}
+
+// writeFile writes a file, keeping a backup of its previous content
+func (j *journal) writeFile(filePath string, content []byte) error {
+	if err := j.mkdirAll(filepath.Dir(filePath)); err != nil {
+		return err
+	}
+
+	old, err := os.ReadFile(filePath)
+	switch {
+	case err == nil:
+		j.backups = append(j.backups, backup{path: filePath, content: old})
+	case os.IsNotExist(err):
+		j.created = append(j.created, filePath)
+	default:
+		return err
+	}
+
+	return os.WriteFile(filePath, content, 0644)
+}
+
+// abort rolls back the journal and returns the original error, annotated
+// with any problem encountered while rolling back
+func (j *journal) abort(err error) error {
+	if rbErr := j.rollback(); rbErr != nil {
+		return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+	}
+	return fmt.Errorf("%w (all changes were rolled back)", err)
+}
+
+// rollback restores overwritten files and removes created files and
+// directories in reverse order
+func (j *journal) rollback() error {
+	var firstErr error
+	keep := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for i := len(j.backups) - 1; i >= 0; i-- {
+		keep(os.WriteFile(j.backups[i].path, j.backups[i].content, 0644))
+	}
+	for i := len(j.created) - 1; i >= 0; i-- {
+		if err := os.Remove(j.created[i]); !os.IsNotExist(err) {
+			keep(err)
+		}
+	}
+	for i := len(j.dirs) - 1; i >= 0; i-- {
+		if err := os.Remove(j.dirs[i]); !os.IsNotExist(err) {
+			keep(err)
+		}
+	}
+	return firstErr
+}
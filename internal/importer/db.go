@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	// Database drivers for Introspect
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// Database drivers supported by Introspect
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// Drivers lists the supported database drivers
+var Drivers = []string{DriverSQLite, DriverPostgres}
+
+// DetectDriver returns the driver a DSN is meant for. Postgres URLs and
+// key=value connection strings select postgres, anything else is taken as
+// a SQLite database file.
+func DetectDriver(dsn string) string {
+	switch {
+	case strings.HasPrefix(dsn, "postgres://"), strings.HasPrefix(dsn, "postgresql://"):
+		return DriverPostgres
+	case strings.Contains(dsn, "dbname=") || strings.Contains(dsn, "host="):
+		return DriverPostgres
+	}
+	return DriverSQLite
+}
+
+// Open opens the database at dsn with driver, failing early when the
+// database cannot be reached. SQLite databases must already exist.
+func Open(ctx context.Context, driver, dsn string) (*sql.DB, error) {
+	switch driver {
+	case DriverSQLite:
+		path := strings.TrimPrefix(dsn, "sqlite://")
+		file, _, _ := strings.Cut(strings.TrimPrefix(path, "file:"), "?")
+		if _, err := os.Stat(file); err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(path, "file:") {
+			path = "file:" + path
+		}
+		if !strings.Contains(path, "mode=") {
+			sep := "?"
+			if strings.Contains(path, "?") {
+				sep = "&"
+			}
+			path += sep + "mode=ro"
+		}
+		dsn = path
+	case DriverPostgres:
+	default:
+		return nil, fmt.Errorf("unknown driver %q (use %s)", driver, strings.Join(Drivers, ", "))
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Introspect reads the tables, columns, keys, indexes and, for Postgres,
+// enum types and domains of the database. schemaName selects the
+// Postgres schema and defaults to public.
+func Introspect(ctx context.Context, db *sql.DB, driver, schemaName string) (*Schema, error) {
+	switch driver {
+	case DriverSQLite:
+		return introspectSQLite(ctx, db)
+	case DriverPostgres:
+		if schemaName == "" {
+			schemaName = "public"
+		}
+		return introspectPostgres(ctx, db, schemaName)
+	}
+	return nil, fmt.Errorf("unknown driver %q (use %s)", driver, strings.Join(Drivers, ", "))
+}
+
+// TableNames returns the names of the tables of the schema
+func (s *Schema) TableNames() []string {
+	names := make([]string, len(s.Tables))
+	for i, table := range s.Tables {
+		names[i] = table.Name
+	}
+	return names
+}
@@ -0,0 +1,464 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+)
+
+// DefaultTypes maps SQL types, without size or precision, to field types
+var DefaultTypes = map[string]string{
+	"smallint": "int16", "int2": "int16", "smallserial": "int16", "serial2": "int16",
+	"integer": "int", "int": "int", "int4": "int", "serial": "int", "serial4": "int", "mediumint": "int",
+	"bigint": "int64", "int8": "int64", "bigserial": "int64", "serial8": "int64",
+	"tinyint": "int8",
+	"real":    "float32", "float4": "float32", "float": "float64",
+	"double precision": "float64", "float8": "float64", "double": "float64",
+	"numeric": config.FieldDecimal, "decimal": config.FieldDecimal, "money": config.FieldMoney,
+	"boolean": "bool", "bool": "bool", "bit": "bool",
+	"text": "string", "varchar": "string", "character varying": "string", "char": "string",
+	"character": "string", "bpchar": "string", "citext": "string", "name": "string",
+	"tinytext": "string", "mediumtext": "string", "longtext": "string", "inet": "string", "cidr": "string",
+	"timestamp": "time.Time", "timestamp without time zone": "time.Time",
+	"timestamptz": "time.Time", "timestamp with time zone": "time.Time",
+	"datetime": "time.Time", "date": "time.Time",
+	"time": "string", "time without time zone": "string", "time with time zone": "string", "interval": "string",
+	"json": config.FieldJSON, "jsonb": config.FieldJSONB,
+	"uuid":  "uuid.UUID",
+	"bytea": "[]byte", "blob": "[]byte", "binary": "[]byte", "varbinary": "[]byte",
+}
+
+// Options configure how a schema is converted into models
+type Options struct {
+	// Types overrides DefaultTypes, e.g. for custom domains. Keys are SQL
+	// types, values field types such as string or decimal(12,2).
+	Types map[string]string
+	// Tables limits the import to the named tables
+	Tables []string
+	// Embedded stores the nested objects of JSON samples in embedded
+	// structs instead of related models
+	Embedded bool
+
+	HasRepo    bool
+	HasService bool
+	HasHandler bool
+}
+
+// Result holds the imported models and the problems that were worked around
+type Result struct {
+	Models []config.ModelConfig
+	// Handlers and Operations are the standalone handlers and the handler
+	// methods beyond CRUD of an imported API contract
+	Handlers   []string
+	Operations []config.OperationConfig
+	Warnings   []string
+}
+
+func (r *Result) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+var sizePattern = regexp.MustCompile(`^(?:character varying|varchar|character|char|bpchar)\((\d+)\)$`)
+
+// converter converts the tables of a schema into models
+type converter struct {
+	schema *Schema
+	opts   Options
+	result *Result
+	// names maps imported table names to model names
+	names map[string]string
+	// joins maps join tables to the model owning the many2many relation
+	joins map[string]string
+}
+
+// Models converts the tables of schema into model configurations. Foreign
+// keys named <name>_id become belongs_to relations and tables only linking
+// two models become many2many relations.
+func Models(schema *Schema, opts Options) (*Result, error) {
+	c := &converter{
+		schema: schema,
+		opts:   opts,
+		result: &Result{},
+		names:  map[string]string{},
+		joins:  map[string]string{},
+	}
+
+	tables, err := c.tables()
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		c.names[table.Name] = modelName(table.Name)
+	}
+	for _, table := range tables {
+		c.joinTable(table)
+	}
+
+	for _, table := range tables {
+		if _, ok := c.joins[table.Name]; ok {
+			continue
+		}
+		model, err := c.model(table)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", table.Name, err)
+		}
+		c.result.Models = append(c.result.Models, model)
+	}
+	return c.result, nil
+}
+
+// tables returns the tables selected by the options
+func (c *converter) tables() ([]*Table, error) {
+	if len(c.opts.Tables) == 0 {
+		return c.schema.Tables, nil
+	}
+	var tables []*Table
+	for _, name := range c.opts.Tables {
+		table := c.schema.Table(name)
+		if table == nil {
+			return nil, fmt.Errorf("table %q not found in schema", name)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// modelName returns the model name of a table
+func modelName(table string) string {
+	return inflect.Pascal(inflect.Singular(table))
+}
+
+// joinTable records table as the join table of a many2many relation when it
+// only holds foreign keys to two other imported models, named the way GORM
+// expects them
+func (c *converter) joinTable(table *Table) {
+	if len(table.Columns) != 2 || len(table.ForeignKeys) != 2 {
+		return
+	}
+	var models []string
+	for i, column := range table.Columns {
+		fk, ok := table.foreignKey(column.Name)
+		target, imported := c.names[fk.Table]
+		if !ok || !imported || fk.Table == table.Name || !c.schema.Table(fk.Table).IsPrimaryKey("id") {
+			return
+		}
+		if column.Name != inflect.Snake(target)+"_id" || (i > 0 && target == models[0]) {
+			return
+		}
+		models = append(models, target)
+	}
+	c.joins[table.Name] = models[0]
+}
+
+// model converts a table into a model
+func (c *converter) model(table *Table) (config.ModelConfig, error) {
+	model := config.ModelConfig{
+		Name:       c.names[table.Name],
+		HasRepo:    c.opts.HasRepo,
+		HasService: c.opts.HasService,
+		HasHandler: c.opts.HasHandler,
+	}
+	if model.TableName() != table.Name {
+		model.Table = table.Name
+	}
+
+	switch {
+	case len(table.PrimaryKey) == 0:
+		c.result.warnf("table %s has no primary key, only its model is generated", table.Name)
+		model.HasRepo, model.HasService, model.HasHandler = false, false, false
+	case table.IsPrimaryKey("id"):
+		model.PrimaryKey = c.keyStrategy(table.Column("id"))
+	default:
+		fields := make([]string, len(table.PrimaryKey))
+		for i, column := range table.PrimaryKey {
+			fields[i] = inflect.Pascal(column)
+		}
+		model.PrimaryKey = config.KeyComposite + "(" + strings.Join(fields, ",") + ")"
+	}
+
+	used := map[string]bool{}
+	for _, column := range table.Columns {
+		field, err := c.field(table, model, column, fieldName(column.Name, used))
+		if err != nil {
+			// Only a broken key makes the whole model unusable
+			if table.inPrimaryKey(column.Name) {
+				return model, fmt.Errorf("column %s: %w", column.Name, err)
+			}
+			c.result.warnf("%s.%s: %v, the column is skipped", table.Name, column.Name, err)
+			continue
+		}
+		used[field.Name] = true
+		// Foreign keys keep their field, which carries the options of the
+		// column, and gain the relation using it
+		if relation, keyType, ok := c.belongsTo(table, column); ok && relation.ForeignKey() == field.Name {
+			field.Type = keyType
+			model.Relations = append(model.Relations, relation)
+		}
+		model.Fields = append(model.Fields, field)
+	}
+
+	var joins []string
+	for joinName, owner := range c.joins {
+		if owner == model.Name {
+			joins = append(joins, joinName)
+		}
+	}
+	sort.Strings(joins)
+	for _, joinName := range joins {
+		join := c.schema.Table(joinName)
+		fk, _ := join.foreignKey(join.Columns[1].Name)
+		relation := config.RelationConfig{
+			Name:  inflect.Plural(c.names[fk.Table]),
+			Kind:  config.RelationManyToMany,
+			Table: joinName,
+		}
+		if relation.Target() != c.names[fk.Table] {
+			relation.Model = c.names[fk.Table]
+		}
+		model.Relations = append(model.Relations, relation)
+	}
+	sort.SliceStable(model.Relations, func(i, j int) bool {
+		return model.Relations[i].Kind == config.RelationBelongsTo && model.Relations[j].Kind != config.RelationBelongsTo
+	})
+
+	model.ApplyPrimaryKey()
+	return model, nil
+}
+
+// fieldName returns an exported field name for a column that is not used
+// yet. Names that would not start with an upper case letter get a Col
+// prefix.
+func fieldName(column string, used map[string]bool) string {
+	name := inflect.Pascal(column)
+	if first, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(first) {
+		name = "Col" + name
+	}
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	return unique
+}
+
+// keyStrategy returns the primary key strategy of an id column
+func (c *converter) keyStrategy(column *Column) string {
+	t, _ := c.fieldType(column.Type)
+	switch {
+	case t == "uuid.UUID":
+		return config.KeyUUID
+	case t == "int64":
+		return config.KeyInt64
+	case strings.HasPrefix(t, "int"):
+		return ""
+	case t == "string" && strings.HasSuffix(column.Type, "(26)"):
+		return config.KeyULID
+	}
+	return config.KeyComposite + "(ID)"
+}
+
+// belongsTo converts a foreign key column named <name>_id referencing the
+// id primary key of an imported model into a belongs_to relation. It also
+// returns the key type of the referenced model, which the column's field
+// must have.
+func (c *converter) belongsTo(table *Table, column *Column) (config.RelationConfig, string, bool) {
+	fk, ok := table.foreignKey(column.Name)
+	if !ok || !strings.HasSuffix(column.Name, "_id") || table.inPrimaryKey(column.Name) {
+		return config.RelationConfig{}, "", false
+	}
+	target, imported := c.names[fk.Table]
+	targetTable := c.schema.Table(fk.Table)
+	if !imported || !targetTable.IsPrimaryKey("id") {
+		return config.RelationConfig{}, "", false
+	}
+	if len(fk.References) == 1 && fk.References[0] != "id" {
+		return config.RelationConfig{}, "", false
+	}
+
+	name := inflect.Pascal(strings.TrimSuffix(column.Name, "_id"))
+	if first, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(first) || table.Column(inflect.Snake(name)) != nil {
+		return config.RelationConfig{}, "", false
+	}
+	relation := config.RelationConfig{Name: name, Kind: config.RelationBelongsTo, OnDelete: fk.OnDelete}
+	if target != relation.Target() {
+		relation.Model = target
+	}
+	targetModel := config.ModelConfig{PrimaryKey: c.keyStrategy(targetTable.Column("id"))}
+	return relation, targetModel.IDType(), true
+}
+
+// field converts a column into the named field. Options that cannot be
+// written in a struct tag are dropped with a warning.
+func (c *converter) field(table *Table, model config.ModelConfig, column *Column, name string) (config.FieldConfig, error) {
+	t, ok := c.fieldType(column.Type)
+	if !ok {
+		c.result.warnf("%s.%s: unknown type %q mapped to string, map it with --type", table.Name, column.Name, column.Type)
+		t = "string"
+	}
+	if column.Name == "deleted_at" && t == "time.Time" {
+		t = "gorm.DeletedAt"
+	}
+
+	field := config.FieldConfig{Name: name, Type: t}
+	if err := field.ParseType(); err != nil {
+		return field, err
+	}
+	if inflect.Snake(name) != column.Name {
+		field.Column = column.Name
+	}
+	if column.Name == "id" && model.PrimaryKey == "" && table.IsPrimaryKey("id") {
+		field.Type = config.KeyUint
+	}
+	if m := sizePattern.FindStringSubmatch(column.Type); m != nil {
+		fmt.Sscan(m[1], &field.Size)
+	}
+
+	key := table.inPrimaryKey(column.Name)
+	managed := column.Name == "created_at" || column.Name == "updated_at" || field.Type == "gorm.DeletedAt"
+	if !column.NotNull && !key && !managed && field.Type != config.FieldJSON && field.Type != config.FieldJSONB && field.Collection == "" && field.Type != "[]byte" {
+		field.Nullable = true
+	}
+	field.Default = column.Default
+	if err := field.CheckOptions(); err != nil && field.Default != "" {
+		c.result.warnf("%s.%s: %v, the default is dropped", table.Name, column.Name, err)
+		field.Default = ""
+	}
+
+	var gorm []string
+	if key && model.KeyStrategy() == config.KeyUint {
+		gorm = append(gorm, "primaryKey")
+	}
+	if column.NotNull && !key && !managed {
+		gorm = append(gorm, "not null")
+	}
+	if column.Identity && !key {
+		gorm = append(gorm, "autoIncrement")
+	}
+	for _, index := range table.Indexes {
+		switch {
+		case len(index.Columns) == 1 && index.Columns[0] == column.Name:
+			if index.Unique {
+				field.Unique = true
+			} else {
+				field.Index = true
+			}
+		case len(index.Columns) > 1 && contains(index.Columns, column.Name):
+			name := index.Name
+			if name == "" {
+				name = "idx_" + table.Name + "_" + strings.Join(index.Columns, "_")
+			}
+			if index.Unique {
+				gorm = append(gorm, "uniqueIndex:"+name)
+			} else {
+				gorm = append(gorm, "index:"+name)
+			}
+		}
+	}
+	if field.Unique && key {
+		field.Unique = false
+	}
+
+	jsonTag := fmt.Sprintf("json:\"%s\"", inflect.Snake(name))
+	if field.Type == "gorm.DeletedAt" {
+		jsonTag = `json:"-"`
+		field.Index = false
+		gorm = append(gorm, "index")
+	}
+	if len(gorm) > 0 {
+		field.Tag = fmt.Sprintf("`gorm:\"%s\" %s`", strings.Join(gorm, ";"), jsonTag)
+	} else {
+		field.Tag = fmt.Sprintf("`%s`", jsonTag)
+	}
+
+	generated := key && !model.IsCompositeKey()
+	if column.NotNull && column.Default == "" && !column.Identity && !generated && !managed && (field.Type == "string" || field.IsEnum()) {
+		field.Validate = "required"
+	}
+	return field, field.CheckOptions()
+}
+
+// fieldType maps a SQL type to a field type, looking at the overrides,
+// enum types, domains and DefaultTypes in turn
+func (c *converter) fieldType(sqlType string) (string, bool) {
+	for depth := 0; depth < 8; depth++ {
+		if t, ok := lookup(c.opts.Types, sqlType); ok {
+			return t, true
+		}
+
+		base := baseType(sqlType)
+		// Native arrays cannot be scanned into JSON serialized slices
+		if strings.HasSuffix(base, "[]") {
+			return "", false
+		}
+
+		if values, ok := c.schema.Enums[base]; ok {
+			return config.FieldEnum + "(" + strings.Join(values, ",") + ")", true
+		}
+		if domain, ok := c.schema.Domains[base]; ok {
+			sqlType = domain
+			continue
+		}
+		if t, ok := DefaultTypes[base]; ok {
+			if t == config.FieldDecimal {
+				if args := typeArgs(sqlType); args != "" {
+					return t + "(" + args + ")", true
+				}
+			}
+			return t, true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// lookup finds a SQL type in the overrides by its full or base type
+func lookup(types map[string]string, sqlType string) (string, bool) {
+	if t, ok := types[sqlType]; ok {
+		return t, true
+	}
+	t, ok := types[baseType(sqlType)]
+	return t, ok
+}
+
+// baseType strips the size and precision of a SQL type, along with
+// MySQL's unsigned modifier
+func baseType(sqlType string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range sqlType {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	base := strings.Join(strings.Fields(b.String()), " ")
+	return strings.TrimSuffix(base, " unsigned")
+}
+
+// typeArgs returns the arguments of a SQL type such as numeric(12,2)
+func typeArgs(sqlType string) string {
+	start := strings.Index(sqlType, "(")
+	end := strings.Index(sqlType, ")")
+	if start < 0 || end < start {
+		return ""
+	}
+	return strings.ReplaceAll(sqlType[start+1:end], " ", "")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
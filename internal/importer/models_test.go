@@ -0,0 +1,246 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+)
+
+// importSQL converts the tables of ddl into models
+func importSQL(t *testing.T, ddl string) *Result {
+	t.Helper()
+	schema, err := ParseSQL(ddl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Models(schema, Options{HasRepo: true, HasService: true, HasHandler: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// findModel returns the named model of result
+func findModel(t *testing.T, result *Result, name string) config.ModelConfig {
+	t.Helper()
+	for _, model := range result.Models {
+		if model.Name == name {
+			return model
+		}
+	}
+	t.Fatalf("model %s not imported, got %v", name, modelNames(result))
+	return config.ModelConfig{}
+}
+
+// findField returns the named field of model
+func findField(t *testing.T, model config.ModelConfig, name string) config.FieldConfig {
+	t.Helper()
+	for _, field := range model.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	t.Fatalf("model %s has no field %s", model.Name, name)
+	return config.FieldConfig{}
+}
+
+func modelNames(result *Result) []string {
+	var names []string
+	for _, model := range result.Models {
+		names = append(names, model.Name)
+	}
+	return names
+}
+
+func TestModelsFields(t *testing.T) {
+	const types = `
+CREATE TYPE order_status AS ENUM ('pending', 'paid');
+CREATE DOMAIN email AS citext;
+CREATE DOMAIN contact AS email;
+`
+	tests := []struct {
+		name    string
+		column  string
+		field   string
+		want    config.FieldConfig
+		warning string
+	}{
+		{
+			name:   "not null string is required",
+			column: "name text NOT NULL",
+			field:  "Name",
+			want:   config.FieldConfig{Name: "Name", Type: "string", Tag: "`gorm:\"not null\" json:\"name\"`", Validate: "required"},
+		},
+		{
+			name:   "not null string with default is optional",
+			column: "label text NOT NULL DEFAULT 'none'",
+			field:  "Label",
+			want:   config.FieldConfig{Name: "Label", Type: "string", Tag: "`gorm:\"not null\" json:\"label\"`", Default: "none"},
+		},
+		{
+			name:   "not null number is not required",
+			column: "quantity integer NOT NULL",
+			field:  "Quantity",
+			want:   config.FieldConfig{Name: "Quantity", Type: "int", Tag: "`gorm:\"not null\" json:\"quantity\"`"},
+		},
+		{
+			name:   "null column is nullable",
+			column: "note varchar(200)",
+			field:  "Note",
+			want:   config.FieldConfig{Name: "Note", Type: "string", Tag: "`json:\"note\"`", Nullable: true, Size: 200},
+		},
+		{
+			name:   "managed timestamp is neither nullable nor required",
+			column: "created_at timestamptz NOT NULL",
+			field:  "CreatedAt",
+			want:   config.FieldConfig{Name: "CreatedAt", Type: "time.Time", Tag: "`json:\"created_at\"`"},
+		},
+		{
+			name:   "enum type",
+			column: "status order_status NOT NULL",
+			field:  "Status",
+			want:   config.FieldConfig{Name: "Status", Type: config.FieldEnum, Tag: "`gorm:\"not null\" json:\"status\"`", Validate: "required", Enum: []string{"pending", "paid"}},
+		},
+		{
+			name:   "enum type with cast default",
+			column: "status order_status NOT NULL DEFAULT 'paid'::order_status",
+			field:  "Status",
+			want:   config.FieldConfig{Name: "Status", Type: config.FieldEnum, Tag: "`gorm:\"not null\" json:\"status\"`", Enum: []string{"pending", "paid"}, Default: "paid"},
+		},
+		{
+			name:   "domain",
+			column: "email email NOT NULL",
+			field:  "Email",
+			want:   config.FieldConfig{Name: "Email", Type: "string", Tag: "`gorm:\"not null\" json:\"email\"`", Validate: "required"},
+		},
+		{
+			name:   "domain of a domain",
+			column: "contact contact",
+			field:  "Contact",
+			want:   config.FieldConfig{Name: "Contact", Type: "string", Tag: "`json:\"contact\"`", Nullable: true},
+		},
+		{
+			name:   "function default is dropped",
+			column: "placed_at timestamptz DEFAULT now()",
+			field:  "PlacedAt",
+			want:   config.FieldConfig{Name: "PlacedAt", Type: "time.Time", Tag: "`json:\"placed_at\"`", Nullable: true},
+		},
+		{
+			name:    "default that cannot be tagged is dropped",
+			column:  "code text NOT NULL DEFAULT 'a;b'",
+			field:   "Code",
+			want:    config.FieldConfig{Name: "Code", Type: "string", Tag: "`gorm:\"not null\" json:\"code\"`"},
+			warning: "orders.code: default \"a;b\" cannot contain ; \" or `, the default is dropped",
+		},
+		{
+			name:    "unknown type",
+			column:  "location point",
+			field:   "Location",
+			want:    config.FieldConfig{Name: "Location", Type: "string", Tag: "`json:\"location\"`", Nullable: true},
+			warning: "orders.location: unknown type \"point\" mapped to string, map it with --type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := importSQL(t, types+"CREATE TABLE orders (id serial PRIMARY KEY, "+tt.column+");")
+			got := findField(t, findModel(t, result, "Order"), tt.field)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("field = %#v, want %#v", got, tt.want)
+			}
+
+			warnings := strings.Join(result.Warnings, "\n")
+			if warnings != tt.warning {
+				t.Errorf("warnings = %q, want %q", warnings, tt.warning)
+			}
+		})
+	}
+}
+
+func TestModelsRelations(t *testing.T) {
+	const customers = "CREATE TABLE customers (id bigserial PRIMARY KEY, name text);\n"
+	const tags = "CREATE TABLE tags (id serial PRIMARY KEY, label text);\n"
+
+	tests := []struct {
+		name   string
+		ddl    string
+		models []string
+		want   []config.RelationConfig
+		// key is the type of the customer_id field when it exists
+		key string
+	}{
+		{
+			name:   "foreign key named after the model",
+			ddl:    customers + "CREATE TABLE orders (id serial PRIMARY KEY, customer_id bigint NOT NULL REFERENCES customers(id));",
+			models: []string{"Customer", "Order"},
+			want:   []config.RelationConfig{{Name: "Customer", Kind: config.RelationBelongsTo}},
+			key:    "int64",
+		},
+		{
+			name:   "foreign key with another name",
+			ddl:    customers + "CREATE TABLE orders (id serial PRIMARY KEY, buyer_id bigint REFERENCES customers);",
+			models: []string{"Customer", "Order"},
+			want:   []config.RelationConfig{{Name: "Buyer", Kind: config.RelationBelongsTo, Model: "Customer"}},
+		},
+		{
+			name:   "on delete cascade",
+			ddl:    customers + "CREATE TABLE orders (id serial PRIMARY KEY, customer_id bigint REFERENCES customers(id) ON DELETE CASCADE);",
+			models: []string{"Customer", "Order"},
+			want:   []config.RelationConfig{{Name: "Customer", Kind: config.RelationBelongsTo, OnDelete: "CASCADE"}},
+			key:    "int64",
+		},
+		{
+			name:   "on delete set null",
+			ddl:    customers + "CREATE TABLE orders (id serial PRIMARY KEY, customer_id integer, FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE SET NULL);",
+			models: []string{"Customer", "Order"},
+			want:   []config.RelationConfig{{Name: "Customer", Kind: config.RelationBelongsTo, OnDelete: "SET NULL"}},
+			key:    "int64",
+		},
+		{
+			name:   "foreign key without id suffix",
+			ddl:    customers + "CREATE TABLE orders (id serial PRIMARY KEY, customer bigint REFERENCES customers(id));",
+			models: []string{"Customer", "Order"},
+		},
+		{
+			name:   "foreign key to a table not imported",
+			ddl:    "CREATE TABLE orders (id serial PRIMARY KEY, customer_id bigint REFERENCES customers(id));",
+			models: []string{"Order"},
+			key:    "int64",
+		},
+		{
+			name:   "join table",
+			ddl:    tags + "CREATE TABLE orders (id serial PRIMARY KEY);\nCREATE TABLE order_tags (order_id integer REFERENCES orders, tag_id integer REFERENCES tags, PRIMARY KEY (order_id, tag_id));",
+			models: []string{"Tag", "Order"},
+			want:   []config.RelationConfig{{Name: "Tags", Kind: config.RelationManyToMany, Table: "order_tags"}},
+		},
+		{
+			name:   "join table with another column",
+			ddl:    tags + "CREATE TABLE orders (id serial PRIMARY KEY);\nCREATE TABLE order_tags (order_id integer REFERENCES orders, tag_id integer REFERENCES tags, position integer, PRIMARY KEY (order_id, tag_id));",
+			models: []string{"Tag", "Order", "OrderTag"},
+		},
+		{
+			name:   "join table with columns named otherwise",
+			ddl:    tags + "CREATE TABLE orders (id serial PRIMARY KEY);\nCREATE TABLE order_tags (o integer REFERENCES orders, t integer REFERENCES tags, PRIMARY KEY (o, t));",
+			models: []string{"Tag", "Order", "OrderTag"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := importSQL(t, tt.ddl)
+			if got := modelNames(result); !reflect.DeepEqual(got, tt.models) {
+				t.Fatalf("models = %v, want %v", got, tt.models)
+			}
+
+			order := findModel(t, result, "Order")
+			if !reflect.DeepEqual(order.Relations, tt.want) {
+				t.Errorf("relations = %#v, want %#v", order.Relations, tt.want)
+			}
+			if tt.key != "" {
+				if got := findField(t, order, "CustomerID").Type; got != tt.key {
+					t.Errorf("CustomerID type = %q, want %q", got, tt.key)
+				}
+			}
+		})
+	}
+}
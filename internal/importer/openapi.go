@@ -0,0 +1,350 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPI is the part of an OpenAPI 3 document models and handlers are
+// generated from. Schemas, properties and paths keep their document order.
+type OpenAPI struct {
+	Schemas []NamedSchema
+	Paths   []PathItem
+}
+
+// NamedSchema is a schema of components/schemas
+type NamedSchema struct {
+	Name   string
+	Schema *SchemaObject
+}
+
+// SchemaObject is an OpenAPI schema reduced to the keywords that map onto
+// fields, relations and validation rules
+type SchemaObject struct {
+	Ref    string        `yaml:"$ref"`
+	Type   schemaTypes   `yaml:"type"`
+	Format string        `yaml:"format"`
+	Enum   []interface{} `yaml:"enum"`
+
+	Items                *SchemaObject        `yaml:"items"`
+	Properties           Properties           `yaml:"properties"`
+	Required             []string             `yaml:"required"`
+	AdditionalProperties additionalProperties `yaml:"additionalProperties"`
+	AllOf                []*SchemaObject      `yaml:"allOf"`
+	OneOf                []*SchemaObject      `yaml:"oneOf"`
+	AnyOf                []*SchemaObject      `yaml:"anyOf"`
+
+	Nullable  bool        `yaml:"nullable"`
+	ReadOnly  bool        `yaml:"readOnly"`
+	WriteOnly bool        `yaml:"writeOnly"`
+	Default   interface{} `yaml:"default"`
+
+	MinLength *int     `yaml:"minLength"`
+	MaxLength *int     `yaml:"maxLength"`
+	Pattern   string   `yaml:"pattern"`
+	Minimum   *float64 `yaml:"minimum"`
+	Maximum   *float64 `yaml:"maximum"`
+	// ExclusiveMinimum and ExclusiveMaximum are booleans in OpenAPI 3.0
+	// and numbers in 3.1
+	ExclusiveMinimum interface{} `yaml:"exclusiveMinimum"`
+	ExclusiveMaximum interface{} `yaml:"exclusiveMaximum"`
+	MinItems         *int        `yaml:"minItems"`
+	MaxItems         *int        `yaml:"maxItems"`
+}
+
+// Property is a named property of an object schema
+type Property struct {
+	Name   string
+	Schema *SchemaObject
+}
+
+// Properties are the properties of an object schema in document order
+type Properties []Property
+
+// UnmarshalYAML decodes the properties mapping keeping its order
+func (p *Properties) UnmarshalYAML(node *yaml.Node) error {
+	return eachPair(node, func(name string, value *yaml.Node) error {
+		schema := &SchemaObject{}
+		if err := value.Decode(schema); err != nil {
+			return fmt.Errorf("property %s: %w", name, err)
+		}
+		*p = append(*p, Property{Name: name, Schema: schema})
+		return nil
+	})
+}
+
+// schemaTypes is the type of a schema, a single name in OpenAPI 3.0 and
+// possibly a list such as [string, "null"] in 3.1
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = schemaTypes{node.Value}
+		return nil
+	}
+	var types []string
+	if err := node.Decode(&types); err != nil {
+		return err
+	}
+	*t = types
+	return nil
+}
+
+// additionalProperties is either a boolean or the schema of map values
+type additionalProperties struct {
+	Schema *SchemaObject
+}
+
+func (a *additionalProperties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return nil
+	}
+	a.Schema = &SchemaObject{}
+	return node.Decode(a.Schema)
+}
+
+// PathItem is a path of the API with its operations
+type PathItem struct {
+	Path       string
+	Operations []Operation
+}
+
+// Operation is an operation of a path
+type Operation struct {
+	// Method is the lower case HTTP method
+	Method      string
+	OperationID string
+	Summary     string
+	Tags        []string
+	// Bodies names the component schemas of the request body and the
+	// success responses
+	Bodies []string
+}
+
+// body is a request body or response, inline or referencing one of the
+// components
+type body struct {
+	Ref     string `yaml:"$ref"`
+	Content map[string]struct {
+		Schema *SchemaObject `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+// schemas returns the names of the component schemas body carries, alone
+// or as array items, resolving references with bodies
+func (b body) schemas(bodies map[string]body) []string {
+	if b.Ref != "" {
+		if target, ok := bodies[b.Ref]; ok && target.Ref == "" {
+			return target.schemas(nil)
+		}
+		return nil
+	}
+	var names []string
+	for _, media := range b.Content {
+		schema := media.Schema
+		if schema != nil && schema.Ref == "" && schema.Items != nil {
+			schema = schema.Items
+		}
+		if schema != nil {
+			if name := refName(schema.Ref); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// operationMethods are the path item keys that are operations handlers
+// are generated for
+var operationMethods = map[string]bool{"get": true, "post": true, "put": true, "patch": true, "delete": true}
+
+// ParseOpenAPI parses an OpenAPI 3 document in YAML or JSON format
+func ParseOpenAPI(data []byte) (*OpenAPI, error) {
+	var doc struct {
+		OpenAPI    string    `yaml:"openapi"`
+		Swagger    string    `yaml:"swagger"`
+		Paths      yaml.Node `yaml:"paths"`
+		Components struct {
+			Schemas       yaml.Node       `yaml:"schemas"`
+			RequestBodies map[string]body `yaml:"requestBodies"`
+			Responses     map[string]body `yaml:"responses"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	switch {
+	case doc.Swagger != "":
+		return nil, fmt.Errorf("swagger %s documents are not supported, convert them to OpenAPI 3 first", doc.Swagger)
+	case !strings.HasPrefix(doc.OpenAPI, "3."):
+		return nil, fmt.Errorf("not an OpenAPI 3 document, the openapi version is missing")
+	}
+
+	// bodies maps references to the request bodies and responses of the
+	// components
+	bodies := map[string]body{}
+	for name, b := range doc.Components.RequestBodies {
+		bodies["#/components/requestBodies/"+name] = b
+	}
+	for name, b := range doc.Components.Responses {
+		bodies["#/components/responses/"+name] = b
+	}
+
+	api := &OpenAPI{}
+	err := eachPair(&doc.Components.Schemas, func(name string, node *yaml.Node) error {
+		schema := &SchemaObject{}
+		if err := node.Decode(schema); err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+		api.Schemas = append(api.Schemas, NamedSchema{Name: name, Schema: schema})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = eachPair(&doc.Paths, func(path string, node *yaml.Node) error {
+		item := PathItem{Path: path}
+		err := eachPair(node, func(method string, node *yaml.Node) error {
+			if !operationMethods[method] {
+				return nil
+			}
+			var op struct {
+				OperationID string          `yaml:"operationId"`
+				Summary     string          `yaml:"summary"`
+				Tags        []string        `yaml:"tags"`
+				RequestBody body            `yaml:"requestBody"`
+				Responses   map[string]body `yaml:"responses"`
+			}
+			if err := node.Decode(&op); err != nil {
+				return fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			operation := Operation{
+				Method:      method,
+				OperationID: op.OperationID,
+				Summary:     op.Summary,
+				Tags:        op.Tags,
+				Bodies:      op.RequestBody.schemas(bodies),
+			}
+			for status, response := range op.Responses {
+				if strings.HasPrefix(status, "2") {
+					operation.Bodies = append(operation.Bodies, response.schemas(bodies)...)
+				}
+			}
+			item.Operations = append(item.Operations, operation)
+			return nil
+		})
+		api.Paths = append(api.Paths, item)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api, nil
+}
+
+// Schema returns the component schema with the given name
+func (a *OpenAPI) Schema(name string) *SchemaObject {
+	for _, schema := range a.Schemas {
+		if schema.Name == name {
+			return schema.Schema
+		}
+	}
+	return nil
+}
+
+// SchemaNames returns the names of the object schemas models can be
+// generated from
+func (a *OpenAPI) SchemaNames() []string {
+	var names []string
+	for _, schema := range a.Schemas {
+		if a.isObject(schema.Schema) {
+			names = append(names, schema.Name)
+		}
+	}
+	return names
+}
+
+// refName returns the schema name of a local #/components/schemas reference
+func refName(ref string) string {
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(ref, prefix) {
+		return ""
+	}
+	return ref[len(prefix):]
+}
+
+// isObject reports whether a schema describes an object with properties,
+// directly or by composing other schemas with allOf
+func (a *OpenAPI) isObject(schema *SchemaObject) bool {
+	return len(a.properties(schema, 0)) > 0
+}
+
+// properties returns the properties of an object schema, merging allOf
+// parts in order
+func (a *OpenAPI) properties(schema *SchemaObject, depth int) Properties {
+	if schema == nil || depth > 8 {
+		return nil
+	}
+	if schema.Ref != "" {
+		return a.properties(a.Schema(refName(schema.Ref)), depth+1)
+	}
+	props := append(Properties{}, schema.Properties...)
+	for _, part := range schema.AllOf {
+		for _, prop := range a.properties(part, depth+1) {
+			if !props.has(prop.Name) {
+				props = append(props, prop)
+			}
+		}
+	}
+	return props
+}
+
+// required returns the required properties of an object schema, merging
+// allOf parts
+func (a *OpenAPI) required(schema *SchemaObject, depth int) map[string]bool {
+	required := map[string]bool{}
+	if schema == nil || depth > 8 {
+		return required
+	}
+	if schema.Ref != "" {
+		return a.required(a.Schema(refName(schema.Ref)), depth+1)
+	}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	for _, part := range schema.AllOf {
+		for name := range a.required(part, depth+1) {
+			required[name] = true
+		}
+	}
+	return required
+}
+
+func (p Properties) has(name string) bool {
+	for _, prop := range p {
+		if prop.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// eachPair calls fn for the key value pairs of a mapping node in order.
+// Other nodes, such as a missing section, have no pairs.
+func eachPair(node *yaml.Node, fn func(key string, value *yaml.Node) error) error {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if err := fn(node.Content[i].Value, node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
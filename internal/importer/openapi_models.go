@@ -0,0 +1,766 @@
+package importer
+
+import (
+	"fmt"
+	gotoken "go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+)
+
+// apiPrefix is the group generated routes are registered on, stripped from
+// contracts that spell it out in their paths
+const apiPrefix = "/api/v1"
+
+// apiConverter converts the schemas and paths of an OpenAPI document
+type apiConverter struct {
+	api    *OpenAPI
+	opts   Options
+	result *Result
+	// names maps imported schema names to model names
+	names map[string]string
+	// keyed records the models that have an ID field
+	keyed map[string]bool
+	// resources maps model names to the resource of their CRUD paths
+	resources map[string]*resource
+}
+
+// resource is the collection path of a model and its CRUD endpoints
+type resource struct {
+	segment   string
+	endpoints map[string]bool
+	// claimed records the endpoints whose operation was converted
+	claimed map[string]bool
+}
+
+// OpenAPIModels converts the object schemas of an OpenAPI document into
+// models and its paths into handler endpoints. Paths shaped like
+// /products and /products/{id} select the CRUD endpoints of the matching
+// model, every other operation becomes a handler method stub.
+func OpenAPIModels(api *OpenAPI, opts Options) (*Result, error) {
+	c := &apiConverter{
+		api:       api,
+		opts:      opts,
+		result:    &Result{},
+		names:     map[string]string{},
+		keyed:     map[string]bool{},
+		resources: map[string]*resource{},
+	}
+
+	schemas, err := c.schemas()
+	if err != nil {
+		return nil, err
+	}
+	for _, schema := range schemas {
+		c.names[schema.Name] = inflect.Pascal(schema.Name)
+	}
+
+	if opts.HasHandler {
+		c.crudPaths()
+	} else if len(api.Paths) > 0 {
+		c.result.warnf("paths are not imported without handlers")
+	}
+	for _, schema := range schemas {
+		name := c.names[schema.Name]
+		if c.hasLayers(name) || c.api.properties(schema.Schema, 0).has("id") {
+			c.keyed[name] = true
+		}
+	}
+
+	for _, schema := range schemas {
+		model, err := c.model(schema)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", schema.Name, err)
+		}
+		c.result.Models = append(c.result.Models, model)
+	}
+	if opts.HasHandler {
+		c.operations()
+	}
+	return c.result, nil
+}
+
+// schemas returns the object schemas selected by the options. Documents
+// with paths only get models for the schemas used as resources.
+func (c *apiConverter) schemas() ([]NamedSchema, error) {
+	var schemas []NamedSchema
+	if len(c.opts.Tables) == 0 {
+		used := c.resourceSchemas()
+		for _, schema := range c.api.Schemas {
+			if !c.api.isObject(schema.Schema) {
+				continue
+			}
+			if used != nil && !used[schema.Name] {
+				c.result.warnf("schema %s: not used by a resource path, skipped (import it with --schemas)", schema.Name)
+				continue
+			}
+			schemas = append(schemas, schema)
+		}
+		return schemas, nil
+	}
+	for _, name := range c.opts.Tables {
+		schema := c.api.Schema(name)
+		if schema == nil {
+			return nil, fmt.Errorf("schema %q not found in components", name)
+		}
+		if !c.api.isObject(schema) {
+			return nil, fmt.Errorf("schema %q is not an object", name)
+		}
+		schemas = append(schemas, NamedSchema{Name: name, Schema: schema})
+	}
+	return schemas, nil
+}
+
+// resourceSchemas returns the schemas the paths use as resources: the
+// schemas named by CRUD paths, the bodies of their create, get and update
+// operations and the schemas with an id these reference. It returns nil
+// for documents without paths.
+func (c *apiConverter) resourceSchemas() map[string]bool {
+	if len(c.api.Paths) == 0 {
+		return nil
+	}
+	used := map[string]bool{}
+	var pending []string
+	use := func(name string) {
+		if schema := c.api.Schema(name); schema != nil && !used[name] && c.api.isObject(schema) {
+			used[name] = true
+			pending = append(pending, name)
+		}
+	}
+
+	for _, item := range c.api.Paths {
+		segments := pathSegments(item.Path)
+		if len(segments) == 0 || len(segments) > 2 || isPathParam(segments[0]) || (len(segments) == 2 && !isPathParam(segments[1])) {
+			continue
+		}
+		name := inflect.Pascal(inflect.Singular(segments[0]))
+		for _, schema := range c.api.Schemas {
+			if inflect.Pascal(schema.Name) == name {
+				use(schema.Name)
+			}
+		}
+		for _, op := range item.Operations {
+			switch crudEndpoint(op.Method, len(segments) == 2) {
+			case config.EndpointCreate, config.EndpointGet, config.EndpointUpdate:
+				for _, body := range op.Bodies {
+					use(body)
+				}
+			}
+		}
+	}
+
+	// Related schemas are referenced by properties, alone or as array
+	// items. Without an id they are stored as JSON and need no model.
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		for _, prop := range c.api.properties(c.api.Schema(name), 0) {
+			schema, ref := c.deref(prop.Schema)
+			if ref == "" && schema.is("array") && schema.Items != nil {
+				schema, ref = c.deref(schema.Items)
+			}
+			if c.api.properties(schema, 0).has("id") {
+				use(ref)
+			}
+		}
+	}
+	return used
+}
+
+// hasLayers reports whether the repository, service and handler of a model
+// are generated. Documents without paths get them for every model, others
+// only for the models their paths expose.
+func (c *apiConverter) hasLayers(model string) bool {
+	if len(c.api.Paths) == 0 || !c.opts.HasHandler {
+		return true
+	}
+	_, ok := c.resources[model]
+	return ok
+}
+
+// model converts an object schema into a model
+func (c *apiConverter) model(schema NamedSchema) (config.ModelConfig, error) {
+	model := config.ModelConfig{Name: c.names[schema.Name]}
+	if c.hasLayers(model.Name) {
+		model.HasRepo, model.HasService, model.HasHandler = c.opts.HasRepo, c.opts.HasService, c.opts.HasHandler
+	}
+	if res, ok := c.resources[model.Name]; ok {
+		if res.segment != inflect.Kebab(inflect.Plural(model.Name)) {
+			model.Path = res.segment
+		}
+		if len(res.endpoints) < len(config.Endpoints) {
+			for _, endpoint := range config.Endpoints {
+				if res.endpoints[endpoint] {
+					model.Endpoints = append(model.Endpoints, endpoint)
+				}
+			}
+		}
+	}
+
+	required := c.api.required(schema.Schema, 0)
+	seen := map[string]bool{}
+	for _, prop := range c.api.properties(schema.Schema, 0) {
+		name := inflect.Pascal(prop.Name)
+		where := schema.Name + "." + prop.Name
+		if !gotoken.IsIdentifier(name) || !gotoken.IsExported(name) {
+			c.result.warnf("%s: property cannot be mapped to a Go field, skipped", where)
+			continue
+		}
+		if seen[name] {
+			c.result.warnf("%s: property clashes with field %s, skipped", where, name)
+			continue
+		}
+		seen[name] = true
+
+		if name == "ID" {
+			model.Fields = append(model.Fields, c.keyField(&model, where, prop))
+			continue
+		}
+		if relation, ok := c.relation(model, where, prop); ok {
+			model.Relations = append(model.Relations, relation)
+			continue
+		}
+		field, err := c.field(where, prop, required[prop.Name])
+		if err != nil {
+			return model, fmt.Errorf("property %s: %w", prop.Name, err)
+		}
+		model.Fields = append(model.Fields, field)
+	}
+
+	// Foreign keys of belongs_to relations replace properties such as
+	// authorId next to an author reference
+	for _, relation := range model.Relations {
+		if relation.Kind != config.RelationBelongsTo {
+			continue
+		}
+		for i, field := range model.Fields {
+			if field.Name == relation.ForeignKey() {
+				c.result.warnf("%s.%s: provided by relation %s as %s", schema.Name, field.JSONKey(), relation.Name, inflect.Snake(field.Name))
+				model.Fields = append(model.Fields[:i], model.Fields[i+1:]...)
+				break
+			}
+		}
+	}
+
+	if c.keyed[model.Name] && !seen["ID"] {
+		id := config.FieldConfig{Name: "ID", Type: config.KeyUint, Tag: "`json:\"id\"`"}
+		model.Fields = append([]config.FieldConfig{id}, model.Fields...)
+	}
+	model.ApplyPrimaryKey()
+	return model, nil
+}
+
+// keyField converts the id property into the primary key of the model
+func (c *apiConverter) keyField(model *config.ModelConfig, where string, prop Property) config.FieldConfig {
+	field := config.FieldConfig{Name: "ID", Type: config.KeyUint}
+	if prop.Name != "id" {
+		field.JSONName = prop.Name
+	}
+	field.Tag = fmt.Sprintf("`json:\"%s\"`", field.JSONKey())
+
+	schema, _ := c.deref(prop.Schema)
+	switch t, _ := c.scalarType(schema); {
+	case t == "uuid.UUID":
+		model.PrimaryKey = config.KeyUUID
+	case t == "int64":
+		model.PrimaryKey = config.KeyInt64
+	case strings.HasPrefix(t, "int"):
+	case t == "string" && schema.MinLength != nil && schema.MaxLength != nil && *schema.MinLength == 26 && *schema.MaxLength == 26:
+		model.PrimaryKey = config.KeyULID
+	case t == "string":
+		field.Type = "string"
+		model.PrimaryKey = config.KeyComposite + "(ID)"
+	default:
+		c.result.warnf("%s: unsupported id type, mapped to uint", where)
+	}
+	return field
+}
+
+// relation converts a reference to another imported model into a
+// belongs_to relation and an array of references into a many2many one
+func (c *apiConverter) relation(model config.ModelConfig, where string, prop Property) (config.RelationConfig, bool) {
+	schema, ref := c.deref(prop.Schema)
+	kind := config.RelationBelongsTo
+	if ref == "" && schema.is("array") && schema.Items != nil {
+		_, ref = c.deref(schema.Items)
+		kind = config.RelationManyToMany
+	}
+	target, ok := c.names[ref]
+	if !ok {
+		return config.RelationConfig{}, false
+	}
+	if !c.keyed[target] || (kind == config.RelationManyToMany && !c.keyed[model.Name]) {
+		c.result.warnf("%s: %s has no id, stored as JSON", where, ref)
+		return config.RelationConfig{}, false
+	}
+
+	relation := config.RelationConfig{Name: inflect.Pascal(prop.Name), Kind: kind}
+	if relation.Target() != target {
+		relation.Model = target
+	}
+	return relation, true
+}
+
+// field converts a property into a field with the validation rules of its
+// schema
+func (c *apiConverter) field(where string, prop Property, required bool) (config.FieldConfig, error) {
+	schema, ref := c.deref(prop.Schema)
+	field := config.FieldConfig{
+		Name:      inflect.Pascal(prop.Name),
+		ReadOnly:  prop.Schema.ReadOnly || schema.ReadOnly,
+		WriteOnly: prop.Schema.WriteOnly || schema.WriteOnly,
+	}
+	if inflect.Snake(field.Name) != prop.Name {
+		field.JSONName = prop.Name
+	}
+	field.Tag = fmt.Sprintf("`json:\"%s\"`", field.JSONKey())
+	if field.ReadOnly && field.WriteOnly {
+		c.result.warnf("%s: both readOnly and writeOnly, neither is kept", where)
+		field.ReadOnly, field.WriteOnly = false, false
+	}
+
+	t, ok := c.fieldType(schema, ref)
+	if !ok {
+		c.result.warnf("%s: unsupported schema mapped to json, map it with --type", where)
+		t = config.FieldJSON
+	}
+	nullable := prop.Schema.Nullable || schema.Nullable || schema.is("null")
+	if nullable && !strings.HasPrefix(t, "[]") && !strings.HasPrefix(t, "map[") && t != config.FieldJSON {
+		t = "*" + t
+	}
+	field.Type = t
+	if err := field.ParseType(); err != nil {
+		return field, err
+	}
+
+	if value, ok := defaultValue(prop.Schema.Default, schema.Default); ok && !strings.ContainsAny(value, ";\"`") {
+		field.Default = value
+	}
+
+	var rules []string
+	if required && field.Type != "bool" {
+		rules = append(rules, "required")
+	}
+	switch {
+	case field.Collection != "":
+		if schema.MinItems != nil {
+			rules = append(rules, "min="+strconv.Itoa(*schema.MinItems))
+		}
+		if schema.MaxItems != nil {
+			rules = append(rules, "max="+strconv.Itoa(*schema.MaxItems))
+		}
+	case field.Type == "string":
+		if rule, ok := formatRules[schema.Format]; ok {
+			rules = append(rules, rule)
+		}
+		if schema.MinLength != nil && *schema.MinLength > 0 {
+			rules = append(rules, "min="+strconv.Itoa(*schema.MinLength))
+		}
+		if schema.MaxLength != nil {
+			field.Size = *schema.MaxLength
+		}
+		if schema.Pattern != "" {
+			c.result.warnf("%s: pattern %q has no validator rule, add a custom one", where, schema.Pattern)
+		}
+	case isNumber(field.Type):
+		if schema.Minimum != nil {
+			rules = append(rules, bound("gte", "gt", *schema.Minimum, schema.ExclusiveMinimum))
+		} else if n, ok := schema.ExclusiveMinimum.(float64); ok {
+			rules = append(rules, "gt="+formatNumber(n))
+		} else if n, ok := schema.ExclusiveMinimum.(int); ok {
+			rules = append(rules, "gt="+strconv.Itoa(n))
+		}
+		if schema.Maximum != nil {
+			rules = append(rules, bound("lte", "lt", *schema.Maximum, schema.ExclusiveMaximum))
+		} else if n, ok := schema.ExclusiveMaximum.(float64); ok {
+			rules = append(rules, "lt="+formatNumber(n))
+		} else if n, ok := schema.ExclusiveMaximum.(int); ok {
+			rules = append(rules, "lt="+strconv.Itoa(n))
+		}
+	}
+
+	optional := field.IsEnum() || field.Nullable || field.Collection != ""
+	if len(rules) > 0 && !required && !optional {
+		rules = append([]string{"omitempty"}, rules...)
+	}
+	field.Validate = strings.Join(rules, ",")
+	return field, nil
+}
+
+// formatRules maps string formats to validator rules
+var formatRules = map[string]string{
+	"date":     "datetime=2006-01-02",
+	"email":    "email",
+	"uri":      "uri",
+	"url":      "url",
+	"hostname": "hostname",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+}
+
+// fieldType maps a schema to a field type: arrays of scalars to slices,
+// maps of scalars to maps, free-form objects to JSON and scalars by their
+// type and format
+func (c *apiConverter) fieldType(schema *SchemaObject, ref string) (string, bool) {
+	if ref != "" && c.api.isObject(schema) {
+		return config.FieldJSON, true
+	}
+	switch {
+	case schema.is("array"):
+		if schema.Items == nil {
+			return config.FieldJSON, true
+		}
+		items, _ := c.deref(schema.Items)
+		if t, ok := c.scalarType(items); ok && !strings.HasPrefix(t, config.FieldEnum) && t != "[]byte" {
+			return "[]" + t, true
+		}
+		return config.FieldJSON, true
+	case schema.is("object") || len(schema.Properties) > 0 || len(schema.AllOf) > 0:
+		if values := schema.AdditionalProperties.Schema; values != nil && len(schema.Properties) == 0 {
+			values, _ = c.deref(values)
+			if t, ok := c.scalarType(values); ok && !strings.HasPrefix(t, config.FieldEnum) && t != "[]byte" {
+				return "map[string]" + t, true
+			}
+		}
+		return config.FieldJSON, true
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0:
+		return config.FieldJSON, true
+	}
+	return c.scalarType(schema)
+}
+
+// scalarType maps a scalar schema to a field type, looking at the --type
+// overrides keyed type:format or format first
+func (c *apiConverter) scalarType(schema *SchemaObject) (string, bool) {
+	typ := schema.primary()
+	if schema.Format != "" {
+		if t, ok := c.opts.Types[typ+":"+schema.Format]; ok {
+			return t, true
+		}
+		if t, ok := c.opts.Types[schema.Format]; ok {
+			return t, true
+		}
+	}
+	if t, ok := c.opts.Types[typ]; ok && typ != "" {
+		return t, true
+	}
+
+	switch typ {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "time.Time", true
+		case "uuid":
+			return "uuid.UUID", true
+		case "byte", "binary":
+			return "[]byte", true
+		case "decimal":
+			return config.FieldDecimal, true
+		}
+		if values, ok := enumValues(schema.Enum); ok {
+			return config.FieldEnum + "(" + strings.Join(values, ",") + ")", true
+		}
+		return "string", true
+	case "integer":
+		switch schema.Format {
+		case "int32":
+			return "int32", true
+		case "int64":
+			return "int64", true
+		}
+		return "int", true
+	case "number":
+		switch schema.Format {
+		case "float":
+			return "float32", true
+		case "decimal":
+			return config.FieldDecimal, true
+		}
+		return "float64", true
+	case "boolean":
+		return "bool", true
+	}
+	return "", false
+}
+
+// deref resolves a reference, or an allOf wrapping a single reference as
+// used to annotate it, returning the schema and the referenced name
+func (c *apiConverter) deref(schema *SchemaObject) (*SchemaObject, string) {
+	ref := ""
+	for depth := 0; depth < 8; depth++ {
+		switch {
+		case schema.Ref != "":
+			ref = refName(schema.Ref)
+			target := c.api.Schema(ref)
+			if target == nil {
+				return &SchemaObject{}, ref
+			}
+			schema = target
+		case len(schema.AllOf) == 1 && len(schema.Properties) == 0:
+			schema = schema.AllOf[0]
+		default:
+			return schema, ref
+		}
+	}
+	return schema, ref
+}
+
+// crudPaths records the CRUD endpoints of paths shaped like /products and
+// /products/{id} whose segment names an imported model
+func (c *apiConverter) crudPaths() {
+	for _, item := range c.api.Paths {
+		segments := pathSegments(item.Path)
+		model, ok := c.pathModel(segments)
+		if !ok || (len(segments) == 2 && !isPathParam(segments[1])) || len(segments) > 2 {
+			continue
+		}
+		for _, op := range item.Operations {
+			endpoint := crudEndpoint(op.Method, len(segments) == 2)
+			res := c.resources[model]
+			if endpoint == "" || (res != nil && res.endpoints[endpoint]) {
+				continue
+			}
+			if res == nil {
+				res = &resource{segment: segments[0], endpoints: map[string]bool{}}
+				c.resources[model] = res
+			}
+			res.endpoints[endpoint] = true
+		}
+	}
+}
+
+// operations converts the operations that are not CRUD endpoints into
+// handler methods, on the model handler when the path belongs to a model
+// resource and on a standalone handler named after the tag otherwise
+func (c *apiConverter) operations() {
+	methods := map[string]map[string]bool{}
+	for _, model := range c.result.Models {
+		if model.HasHandler {
+			methods[model.Name] = map[string]bool{"RegisterRoutes": true, "RegisterReadRoutes": true, "RegisterWriteRoutes": true}
+			for _, name := range model.HandlerMethods() {
+				methods[model.Name][name] = true
+			}
+		}
+	}
+
+	for _, item := range c.api.Paths {
+		segments := pathSegments(item.Path)
+		model, _ := c.pathModel(segments)
+		res := c.resources[model]
+		for _, op := range item.Operations {
+			where := strings.ToUpper(op.Method) + " " + item.Path
+			if endpoint := crudEndpoint(op.Method, len(segments) == 2); res != nil && len(segments) <= 2 && endpoint != "" {
+				if !res.claim(endpoint) {
+					c.result.warnf("%s: duplicates the %s endpoint of %s, skipped", where, endpoint, model)
+				}
+				continue
+			}
+
+			handler := model
+			if res == nil {
+				handler = c.handlerName(op, segments)
+			}
+			if methods[handler] == nil {
+				methods[handler] = map[string]bool{"RegisterRoutes": true, "Health": true}
+				c.result.Handlers = append(c.result.Handlers, handler)
+			}
+
+			name := inflect.Pascal(op.OperationID)
+			if name == "" || methods[handler][name] {
+				name = pathOperationName(op.Method, segments)
+			}
+			if !gotoken.IsIdentifier(name) || !gotoken.IsExported(name) {
+				c.result.warnf("%s: operation name %q is not a Go identifier, skipped", where, name)
+				continue
+			}
+			if methods[handler][name] {
+				c.result.warnf("%s: method %s already exists on %sHandler, skipped", where, name, handler)
+				continue
+			}
+			methods[handler][name] = true
+
+			c.result.Operations = append(c.result.Operations, config.OperationConfig{
+				Handler: handler,
+				Name:    name,
+				Method:  strings.ToUpper(op.Method),
+				Path:    "/" + strings.Join(segments, "/"),
+				Summary: strings.TrimSpace(strings.Split(op.Summary, "\n")[0]),
+			})
+		}
+	}
+	sort.Strings(c.result.Handlers)
+}
+
+// claim reports whether an operation is the first one seen for a CRUD
+// endpoint
+func (r *resource) claim(endpoint string) bool {
+	if r.claimed == nil {
+		r.claimed = map[string]bool{}
+	}
+	if r.claimed[endpoint] {
+		return false
+	}
+	r.claimed[endpoint] = true
+	return true
+}
+
+// pathModel returns the imported model named by the first path segment
+func (c *apiConverter) pathModel(segments []string) (string, bool) {
+	if len(segments) == 0 || isPathParam(segments[0]) {
+		return "", false
+	}
+	name := inflect.Pascal(inflect.Singular(segments[0]))
+	for _, model := range c.names {
+		if model == name {
+			return model, true
+		}
+	}
+	return "", false
+}
+
+// handlerName names the standalone handler of an operation after its
+// first tag or path segment
+func (c *apiConverter) handlerName(op Operation, segments []string) string {
+	name := ""
+	if len(op.Tags) > 0 {
+		name = inflect.Pascal(op.Tags[0])
+	}
+	if (name == "" || !gotoken.IsIdentifier(name)) && len(segments) > 0 && !isPathParam(segments[0]) {
+		name = inflect.Pascal(segments[0])
+	}
+	if name == "" || !gotoken.IsIdentifier(name) {
+		name = "API"
+	}
+	return name
+}
+
+// pathOperationName names an operation after its method and path, for
+// operations without an operationId or whose operationId is taken
+func pathOperationName(method string, segments []string) string {
+	words := []string{method}
+	for _, segment := range segments {
+		if isPathParam(segment) {
+			words = append(words, "by", strings.Trim(segment, "{}"))
+		} else {
+			words = append(words, segment)
+		}
+	}
+	return inflect.Pascal(strings.Join(words, "_"))
+}
+
+// crudEndpoint returns the CRUD endpoint of a method on a collection or
+// item path. PATCH is not one, the generated update replaces the record.
+func crudEndpoint(method string, item bool) string {
+	switch {
+	case !item && method == "get":
+		return config.EndpointList
+	case !item && method == "post":
+		return config.EndpointCreate
+	case item && method == "get":
+		return config.EndpointGet
+	case item && method == "put":
+		return config.EndpointUpdate
+	case item && method == "delete":
+		return config.EndpointDelete
+	}
+	return ""
+}
+
+// pathSegments splits a path relative to the API group into segments
+func pathSegments(path string) []string {
+	if rest := strings.TrimPrefix(path, apiPrefix); rest != path && (rest == "" || rest[0] == '/') {
+		path = rest
+	}
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// is reports whether the schema has the type, alone or in a 3.1 type list
+func (s *SchemaObject) is(typ string) bool {
+	for _, t := range s.Type {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// primary returns the type of a schema without the 3.1 "null" type.
+// Enums without a type are strings.
+func (s *SchemaObject) primary() string {
+	for _, t := range s.Type {
+		if t != "null" {
+			return t
+		}
+	}
+	if len(s.Enum) > 0 {
+		return "string"
+	}
+	return ""
+}
+
+// enumValues returns the values of a string enum when they are valid enum
+// field values
+func enumValues(enum []interface{}) ([]string, bool) {
+	var values []string
+	for _, v := range enum {
+		if v == nil {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, s)
+	}
+	if config.CheckEnum(values) != nil {
+		return nil, false
+	}
+	return values, true
+}
+
+// defaultValue formats the first scalar default as a column default
+func defaultValue(values ...interface{}) (string, bool) {
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			return v, v != ""
+		case bool:
+			return strconv.FormatBool(v), true
+		case int:
+			return strconv.Itoa(v), true
+		case float64:
+			return formatNumber(v), true
+		}
+	}
+	return "", false
+}
+
+// bound returns the validator rule of a minimum or maximum, which is
+// exclusive when the OpenAPI 3.0 exclusive flag is set
+func bound(inclusive, exclusive string, n float64, flag interface{}) string {
+	if b, ok := flag.(bool); ok && b {
+		return exclusive + "=" + formatNumber(n)
+	}
+	return inclusive + "=" + formatNumber(n)
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func isNumber(t string) bool {
+	return strings.HasPrefix(t, "int") || strings.HasPrefix(t, "uint") || strings.HasPrefix(t, "float")
+}
@@ -0,0 +1,241 @@
+package importer
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+)
+
+// productDocument is an OpenAPI document whose Product schema gets the
+// required list and property of a test case
+const productDocument = `
+openapi: 3.0.3
+info: {title: Shop, version: 1.0.0}
+components:
+  schemas:
+    Status: {type: string, enum: [draft, published]}
+    Person:
+      type: object
+      properties:
+        id: {type: integer}
+    Product:
+      type: object
+      required: [%s]
+      properties:
+        id: %s
+        %s
+`
+
+// importOpenAPI converts the schemas and paths of document into models
+func importOpenAPI(t *testing.T, document string) *Result {
+	t.Helper()
+	api, err := ParseOpenAPI([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := OpenAPIModels(api, Options{HasRepo: true, HasService: true, HasHandler: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestOpenAPIModelsFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		required string
+		property string
+		field    string
+		want     config.FieldConfig
+	}{
+		{
+			name:     "required string with max length",
+			required: "name",
+			property: "name: {type: string, maxLength: 120}",
+			field:    "Name",
+			want:     config.FieldConfig{Name: "Name", Type: "string", Tag: "`json:\"name\"`", Validate: "required", Size: 120},
+		},
+		{
+			name:     "string format",
+			property: "email: {type: string, format: email, minLength: 3}",
+			field:    "Email",
+			want:     config.FieldConfig{Name: "Email", Type: "string", Tag: "`json:\"email\"`", Validate: "omitempty,email,min=3"},
+		},
+		{
+			name:     "uuid format",
+			property: "ref: {type: string, format: uuid}",
+			field:    "Ref",
+			want:     config.FieldConfig{Name: "Ref", Type: "uuid.UUID", Tag: "`json:\"ref\"`"},
+		},
+		{
+			name:     "nullable integer with bounds",
+			property: "age: {type: integer, minimum: 0, maximum: 150, nullable: true}",
+			field:    "Age",
+			want:     config.FieldConfig{Name: "Age", Type: "int", Tag: "`json:\"age\"`", Validate: "gte=0,lte=150", Nullable: true},
+		},
+		{
+			name:     "exclusive minimum",
+			required: "price",
+			property: "price: {type: number, format: double, minimum: 0, exclusiveMinimum: true}",
+			field:    "Price",
+			want:     config.FieldConfig{Name: "Price", Type: "float64", Tag: "`json:\"price\"`", Validate: "required,gt=0"},
+		},
+		{
+			name:     "default",
+			property: "label: {type: string, default: none}",
+			field:    "Label",
+			want:     config.FieldConfig{Name: "Label", Type: "string", Tag: "`json:\"label\"`", Default: "none"},
+		},
+		{
+			name:     "write only",
+			property: "password: {type: string, writeOnly: true}",
+			field:    "Password",
+			want:     config.FieldConfig{Name: "Password", Type: "string", Tag: "`json:\"password\"`", WriteOnly: true},
+		},
+		{
+			name:     "read only camelCase key",
+			property: "createdAt: {type: string, format: date-time, readOnly: true}",
+			field:    "CreatedAt",
+			want:     config.FieldConfig{Name: "CreatedAt", Type: "time.Time", Tag: "`json:\"createdAt\"`", JSONName: "createdAt", ReadOnly: true},
+		},
+		{
+			name:     "enum reference",
+			property: "status: {$ref: '#/components/schemas/Status'}",
+			field:    "Status",
+			want:     config.FieldConfig{Name: "Status", Type: config.FieldEnum, Tag: "`json:\"status\"`", Enum: []string{"draft", "published"}},
+		},
+		{
+			name:     "inline enum",
+			required: "level",
+			property: "level: {type: string, enum: [low, high]}",
+			field:    "Level",
+			want:     config.FieldConfig{Name: "Level", Type: config.FieldEnum, Tag: "`json:\"level\"`", Validate: "required", Enum: []string{"low", "high"}},
+		},
+		{
+			name:     "array of scalars",
+			property: "labels: {type: array, items: {type: string}, maxItems: 10}",
+			field:    "Labels",
+			want:     config.FieldConfig{Name: "Labels", Type: "string", Tag: "`json:\"labels\"`", Validate: "max=10", Collection: config.CollectionSlice},
+		},
+		{
+			name:     "map of scalars",
+			property: "attributes: {type: object, additionalProperties: {type: string}}",
+			field:    "Attributes",
+			want:     config.FieldConfig{Name: "Attributes", Type: "string", Tag: "`json:\"attributes\"`", Collection: config.CollectionMap},
+		},
+		{
+			name:     "free-form object",
+			property: "meta: {type: object}",
+			field:    "Meta",
+			want:     config.FieldConfig{Name: "Meta", Type: config.FieldJSON, Tag: "`json:\"meta\"`"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := importOpenAPI(t, fmt.Sprintf(productDocument, tt.required, "{type: integer}", tt.property))
+			got := findField(t, findModel(t, result, "Product"), tt.field)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("field = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAPIModelsKeys(t *testing.T) {
+	tests := []struct {
+		id         string
+		primaryKey string
+		keyType    string
+	}{
+		{"{type: integer}", "", config.KeyUint},
+		{"{type: integer, format: int64}", config.KeyInt64, "int64"},
+		{"{type: string, format: uuid}", config.KeyUUID, "uuid.UUID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			product := findModel(t, importOpenAPI(t, fmt.Sprintf(productDocument, "", tt.id, "")), "Product")
+			if product.PrimaryKey != tt.primaryKey {
+				t.Errorf("primary key = %q, want %q", product.PrimaryKey, tt.primaryKey)
+			}
+			if got := findField(t, product, "ID").Type; got != tt.keyType {
+				t.Errorf("ID type = %q, want %q", got, tt.keyType)
+			}
+		})
+	}
+}
+
+func TestOpenAPIModelsRelations(t *testing.T) {
+	tests := []struct {
+		name     string
+		property string
+		want     []config.RelationConfig
+	}{
+		{
+			name:     "reference",
+			property: "owner: {$ref: '#/components/schemas/Person'}",
+			want:     []config.RelationConfig{{Name: "Owner", Kind: config.RelationBelongsTo, Model: "Person"}},
+		},
+		{
+			name:     "array of references",
+			property: "people: {type: array, items: {$ref: '#/components/schemas/Person'}}",
+			want:     []config.RelationConfig{{Name: "People", Kind: config.RelationManyToMany}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := findModel(t, importOpenAPI(t, fmt.Sprintf(productDocument, "", "{type: integer}", tt.property)), "Product")
+			if !reflect.DeepEqual(product.Relations, tt.want) {
+				t.Errorf("relations = %#v, want %#v", product.Relations, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAPIModelsPaths(t *testing.T) {
+	result := importOpenAPI(t, `
+openapi: 3.0.3
+info: {title: Shop, version: 1.0.0}
+paths:
+  /api/v1/products:
+    get: {operationId: listProducts}
+    post: {operationId: createProduct}
+  /api/v1/products/{productId}:
+    get: {operationId: getProduct}
+    patch: {operationId: patchProduct}
+  /api/v1/products/{productId}/publish:
+    post: {operationId: publishProduct, summary: Publish a product}
+  /api/v1/reports/sales:
+    get: {operationId: salesReport, tags: [reports]}
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id: {type: integer}
+    Draft:
+      type: object
+      properties:
+        id: {type: integer}
+`)
+
+	if got, want := modelNames(result), []string{"Product"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("models = %v, want %v", got, want)
+	}
+	product := findModel(t, result, "Product")
+	if want := []string{"create", "get", "list"}; !reflect.DeepEqual(product.Endpoints, want) {
+		t.Errorf("endpoints = %v, want %v", product.Endpoints, want)
+	}
+	if want := []string{"Reports"}; !reflect.DeepEqual(result.Handlers, want) {
+		t.Errorf("handlers = %v, want %v", result.Handlers, want)
+	}
+	want := []config.OperationConfig{
+		{Handler: "Product", Name: "PatchProduct", Method: "PATCH", Path: "/products/{productId}"},
+		{Handler: "Product", Name: "PublishProduct", Method: "POST", Path: "/products/{productId}/publish", Summary: "Publish a product"},
+		{Handler: "Reports", Name: "SalesReport", Method: "GET", Path: "/reports/sales"},
+	}
+	if !reflect.DeepEqual(result.Operations, want) {
+		t.Errorf("operations = %#v, want %#v", result.Operations, want)
+	}
+}
@@ -0,0 +1,230 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// introspectPostgres reads the schema of a Postgres database. Tables and
+// columns come from information_schema; keys and indexes from pg_catalog,
+// which keeps the column order of multi column constraints.
+func introspectPostgres(ctx context.Context, db *sql.DB, schemaName string) (*Schema, error) {
+	schema := NewSchema()
+
+	steps := []func(context.Context, *sql.DB, string, *Schema) error{
+		postgresTables,
+		postgresColumns,
+		postgresEnums,
+		postgresConstraints,
+		postgresIndexes,
+	}
+	for _, step := range steps {
+		if err := step(ctx, db, schemaName, schema); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+func postgresTables(ctx context.Context, db *sql.DB, schemaName string, schema *Schema) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_schema = $1 AND table_type = 'BASE TABLE'
+		ORDER BY table_name`, schemaName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		schema.Tables = append(schema.Tables, &Table{Name: name})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(schema.Tables) == 0 {
+		return fmt.Errorf("no tables found in schema %q", schemaName)
+	}
+	return nil
+}
+
+func postgresColumns(ctx context.Context, db *sql.DB, schemaName string, schema *Schema) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT table_name, column_name, data_type, udt_name, domain_name,
+			character_maximum_length, numeric_precision, numeric_scale,
+			is_nullable = 'NO', column_default, is_identity = 'YES'
+		FROM information_schema.columns
+		WHERE table_schema = $1
+		ORDER BY table_name, ordinal_position`, schemaName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			tableName, name, dataType, udtName string
+			domain, dflt                       sql.NullString
+			length, precision, scale           sql.NullInt64
+			notNull, identity                  bool
+		)
+		if err := rows.Scan(&tableName, &name, &dataType, &udtName, &domain, &length, &precision, &scale, &notNull, &dflt, &identity); err != nil {
+			return err
+		}
+		table := schema.Table(tableName)
+		if table == nil {
+			continue
+		}
+
+		typ := postgresType(dataType, udtName, length, precision, scale)
+		// Domains keep their name so they can be mapped, falling back to
+		// the underlying type
+		if domain.Valid {
+			schema.Domains[domain.String] = typ
+			typ = domain.String
+		}
+
+		column := &Column{Name: name, Type: typ, NotNull: notNull, Identity: identity}
+		if dflt.Valid {
+			column.Default = parseDefault(dflt.String, column)
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	return rows.Err()
+}
+
+// postgresType builds the SQL type of a column from information_schema
+func postgresType(dataType, udtName string, length, precision, scale sql.NullInt64) string {
+	switch dataType {
+	case "USER-DEFINED":
+		return udtName
+	case "ARRAY":
+		return strings.TrimPrefix(udtName, "_") + "[]"
+	case "character varying", "character":
+		if length.Valid {
+			return fmt.Sprintf("%s(%d)", dataType, length.Int64)
+		}
+	case "numeric":
+		if precision.Valid && scale.Valid {
+			return fmt.Sprintf("numeric(%d,%d)", precision.Int64, scale.Int64)
+		}
+	}
+	return dataType
+}
+
+func postgresEnums(ctx context.Context, db *sql.DB, schemaName string, schema *Schema) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT t.typname, e.enumlabel
+		FROM pg_type t
+		JOIN pg_enum e ON e.enumtypid = t.oid
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE n.nspname = $1
+		ORDER BY t.typname, e.enumsortorder`, schemaName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return err
+		}
+		schema.Enums[name] = append(schema.Enums[name], value)
+	}
+	return rows.Err()
+}
+
+// postgresColumnList selects the ordered column names of an attribute
+// number array of the relation rel
+const postgresColumnList = `array_to_string(ARRAY(
+	SELECT a.attname FROM unnest(%s) WITH ORDINALITY k(num, pos)
+	JOIN pg_attribute a ON a.attrelid = %s AND a.attnum = k.num
+	ORDER BY k.pos), ',')`
+
+// postgresActions maps the ON DELETE codes of pg_constraint to their
+// actions, leaving out the default NO ACTION
+var postgresActions = map[string]string{"r": "RESTRICT", "c": "CASCADE", "n": "SET NULL", "d": "SET DEFAULT"}
+
+func postgresConstraints(ctx context.Context, db *sql.DB, schemaName string, schema *Schema) error {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT c.conname, c.contype, t.relname, %s, COALESCE(f.relname, ''), %s, c.confdeltype
+		FROM pg_constraint c
+		JOIN pg_class t ON t.oid = c.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		LEFT JOIN pg_class f ON f.oid = c.confrelid
+		WHERE n.nspname = $1 AND c.contype IN ('p', 'u', 'f')
+		ORDER BY t.relname, c.conname`,
+		fmt.Sprintf(postgresColumnList, "c.conkey", "c.conrelid"),
+		fmt.Sprintf(postgresColumnList, "c.confkey", "c.confrelid"),
+	), schemaName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, kind, tableName, columns, target, references, onDelete string
+		if err := rows.Scan(&name, &kind, &tableName, &columns, &target, &references, &onDelete); err != nil {
+			return err
+		}
+		table := schema.Table(tableName)
+		if table == nil {
+			continue
+		}
+
+		switch kind {
+		case "p":
+			table.PrimaryKey = strings.Split(columns, ",")
+		case "u":
+			table.Indexes = append(table.Indexes, Index{Name: name, Columns: strings.Split(columns, ","), Unique: true})
+		case "f":
+			table.ForeignKeys = append(table.ForeignKeys, ForeignKey{
+				Columns:    strings.Split(columns, ","),
+				Table:      target,
+				References: strings.Split(references, ","),
+				OnDelete:   postgresActions[onDelete],
+			})
+		}
+	}
+	return rows.Err()
+}
+
+func postgresIndexes(ctx context.Context, db *sql.DB, schemaName string, schema *Schema) error {
+	// Indexes backing constraints, on expressions or partial are skipped
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT i.relname, t.relname, x.indisunique, %s
+		FROM pg_index x
+		JOIN pg_class i ON i.oid = x.indexrelid
+		JOIN pg_class t ON t.oid = x.indrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE n.nspname = $1
+			AND x.indexprs IS NULL AND x.indpred IS NULL
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = x.indexrelid)
+		ORDER BY t.relname, i.relname`,
+		fmt.Sprintf(postgresColumnList, "x.indkey::int2[]", "x.indrelid"),
+	), schemaName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, tableName, columns string
+		var unique bool
+		if err := rows.Scan(&name, &tableName, &unique, &columns); err != nil {
+			return err
+		}
+		if table := schema.Table(tableName); table != nil {
+			table.Indexes = append(table.Indexes, Index{Name: name, Columns: strings.Split(columns, ","), Unique: unique})
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,410 @@
+package importer
+
+import (
+	"fmt"
+	gotoken "go/token"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+	"gopkg.in/yaml.v3"
+)
+
+// shape is the structure inferred from sample values. Objects keep the
+// order of their keys, arrays merge the shapes of their elements.
+type shape struct {
+	kind shapeKind
+	// scalar is the field type of a scalar
+	scalar   string
+	nullable bool
+	keys     []string
+	props    map[string]*shape
+	elem     *shape
+}
+
+type shapeKind int
+
+const (
+	shapeNull shapeKind = iota
+	shapeScalar
+	shapeObject
+	shapeArray
+	// shapeMixed holds values of different kinds, stored as JSON
+	shapeMixed
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// SampleModels infers a model named name from a sample JSON payload. Keys
+// become fields keeping their casing as JSON keys, nested objects become
+// belongs_to models, or embedded structs with Options.Embedded, and arrays
+// of objects has_many models. Nested models are generated without layers
+// and named so they do not clash with the existing models.
+func SampleModels(name string, data []byte, opts Options, existing []string) (*Result, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("sample is empty")
+	}
+	root := inferShape(doc.Content[0])
+	if root.kind == shapeArray && root.elem != nil {
+		root = root.elem
+	}
+	if root.kind != shapeObject {
+		return nil, fmt.Errorf("sample must be an object or an array of objects")
+	}
+
+	s := &sampler{opts: opts, result: &Result{}, names: map[string]bool{name: true}}
+	for _, model := range existing {
+		s.names[model] = true
+	}
+	model := config.ModelConfig{
+		Name:       name,
+		Fields:     config.DefaultModelFields(),
+		HasRepo:    opts.HasRepo,
+		HasService: opts.HasService,
+		HasHandler: opts.HasHandler,
+	}
+	s.fields(&model, root, "")
+	model.ApplyPrimaryKey()
+	s.result.Models = append(s.result.Models, model)
+	return s.result, nil
+}
+
+// inferShape infers the shape of a decoded value
+func inferShape(node *yaml.Node) *shape {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return inferShape(node.Alias)
+	case yaml.MappingNode:
+		s := &shape{kind: shapeObject, props: map[string]*shape{}}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if _, ok := s.props[key]; !ok {
+				s.keys = append(s.keys, key)
+			}
+			s.props[key] = inferShape(node.Content[i+1])
+		}
+		return s
+	case yaml.SequenceNode:
+		s := &shape{kind: shapeArray}
+		for _, item := range node.Content {
+			s.elem = mergeShapes(s.elem, inferShape(item))
+		}
+		return s
+	}
+
+	switch node.Tag {
+	case "!!null":
+		return &shape{kind: shapeNull, nullable: true}
+	case "!!bool":
+		return &shape{kind: shapeScalar, scalar: "bool"}
+	case "!!int":
+		n, err := strconv.ParseInt(node.Value, 0, 64)
+		if err != nil || n > math.MaxInt32 || n < math.MinInt32 {
+			return &shape{kind: shapeScalar, scalar: "int64"}
+		}
+		return &shape{kind: shapeScalar, scalar: "int"}
+	case "!!float":
+		return &shape{kind: shapeScalar, scalar: "float64"}
+	}
+	return &shape{kind: shapeScalar, scalar: stringType(node.Value)}
+}
+
+// stringType recognizes timestamps and UUIDs in string values. Dates
+// without a time stay strings, time.Time only decodes RFC 3339.
+func stringType(value string) string {
+	if _, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return "time.Time"
+	}
+	if uuidPattern.MatchString(value) {
+		return "uuid.UUID"
+	}
+	return "string"
+}
+
+// mergeShapes combines the shapes of two values found at the same place,
+// such as the elements of an array
+func mergeShapes(a, b *shape) *shape {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.kind == shapeNull:
+		b.nullable = true
+		return b
+	case b.kind == shapeNull:
+		a.nullable = true
+		return a
+	case a.kind != b.kind:
+		return &shape{kind: shapeMixed, nullable: a.nullable || b.nullable}
+	}
+
+	merged := &shape{kind: a.kind, nullable: a.nullable || b.nullable}
+	switch a.kind {
+	case shapeScalar:
+		switch {
+		case a.scalar == b.scalar:
+			merged.scalar = a.scalar
+		case isInteger(a.scalar) && isInteger(b.scalar):
+			merged.scalar = "int64"
+		case isSampleNumber(a.scalar) && isSampleNumber(b.scalar):
+			merged.scalar = "float64"
+		case a.scalar != "bool" && b.scalar != "bool" && !isSampleNumber(a.scalar) && !isSampleNumber(b.scalar):
+			merged.scalar = "string"
+		default:
+			return &shape{kind: shapeMixed, nullable: merged.nullable}
+		}
+	case shapeObject:
+		merged.props = map[string]*shape{}
+		for _, key := range append(append([]string{}, a.keys...), b.keys...) {
+			if _, ok := merged.props[key]; ok {
+				continue
+			}
+			merged.keys = append(merged.keys, key)
+			merged.props[key] = mergeShapes(a.props[key], b.props[key])
+		}
+	case shapeArray:
+		merged.elem = mergeShapes(a.elem, b.elem)
+	}
+	return merged
+}
+
+func isInteger(t string) bool {
+	return t == "int" || t == "int64"
+}
+
+func isSampleNumber(t string) bool {
+	return isInteger(t) || t == "float64"
+}
+
+// sampler converts inferred shapes into models
+type sampler struct {
+	opts   Options
+	result *Result
+	// names records the model names in use
+	names map[string]bool
+}
+
+// fields adds the fields and relations of an object shape to a model. path
+// locates the object in the sample for warnings.
+func (s *sampler) fields(model *config.ModelConfig, object *shape, path string) {
+	seen := map[string]bool{}
+	for _, field := range model.Fields {
+		seen[field.Name] = true
+	}
+	inferred := map[string]bool{}
+
+	// The key comes first, relations to the model depend on its type
+	if id, ok := object.props["id"]; ok && !model.Embedded && id.kind == shapeScalar {
+		s.keyField(model, id, path+"id")
+	}
+
+	for _, key := range object.keys {
+		value := object.props[key]
+		where := path + key
+		name := inflect.Pascal(key)
+		if !gotoken.IsIdentifier(name) || !gotoken.IsExported(name) {
+			s.result.warnf("%s: key cannot be mapped to a Go field, skipped", where)
+			continue
+		}
+		if inferred[name] {
+			s.result.warnf("%s: key clashes with field %s, skipped", where, name)
+			continue
+		}
+		inferred[name] = true
+
+		if value.kind == shapeObject && len(value.keys) > 0 {
+			s.object(model, key, name, value, where)
+			continue
+		}
+		if value.kind == shapeArray && value.elem != nil && value.elem.kind == shapeObject && len(value.elem.keys) > 0 && !s.opts.Embedded && !model.Embedded {
+			s.collection(model, key, name, value.elem, where)
+			continue
+		}
+
+		field := config.FieldConfig{Name: name, Type: s.fieldType(value, where)}
+		if inflect.Snake(name) != key {
+			field.JSONName = key
+		}
+		field.Tag = fmt.Sprintf("`json:\"%s\"`", field.JSONKey())
+		if err := field.ParseType(); err != nil {
+			s.result.warnf("%s: %v, stored as JSON", where, err)
+			field = config.FieldConfig{Name: name, Type: config.FieldJSON, JSONName: field.JSONName, Tag: field.Tag}
+		}
+
+		if name == "ID" && !model.Embedded {
+			if key != "id" || value.kind != shapeScalar {
+				s.result.warnf("%s: clashes with the primary key, skipped", where)
+			}
+			continue
+		}
+		if seen[name] {
+			// Sample keys such as createdAt replace the default fields
+			for i := range model.Fields {
+				if model.Fields[i].Name == name {
+					model.Fields[i].JSONName = field.JSONName
+					model.Fields[i].Tag = fmt.Sprintf("`json:\"%s\"`", field.JSONKey())
+				}
+			}
+			continue
+		}
+		seen[name] = true
+		model.Fields = append(model.Fields, field)
+	}
+
+	// Foreign keys of belongs_to relations replace keys such as customerId
+	// next to a customer object
+	for _, relation := range model.Relations {
+		if relation.Kind != config.RelationBelongsTo {
+			continue
+		}
+		for i, field := range model.Fields {
+			if field.Name == relation.ForeignKey() {
+				s.result.warnf("%s%s: provided by relation %s as %s", path, field.JSONKey(), relation.Name, inflect.Snake(field.Name))
+				model.Fields = append(model.Fields[:i], model.Fields[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// fieldType returns the field type of a scalar or scalar array shape,
+// anything else is stored as JSON
+func (s *sampler) fieldType(value *shape, where string) string {
+	switch value.kind {
+	case shapeScalar:
+		if value.nullable {
+			return "*" + value.scalar
+		}
+		return value.scalar
+	case shapeArray:
+		if value.elem != nil && value.elem.kind == shapeScalar {
+			return "[]" + value.elem.scalar
+		}
+		if value.elem == nil || value.elem.kind == shapeNull {
+			s.result.warnf("%s: empty array in sample, stored as JSON", where)
+		}
+	case shapeNull:
+		s.result.warnf("%s: only null in sample, stored as JSON", where)
+	}
+	return config.FieldJSON
+}
+
+// keyField uses the id of the sample as primary key, picking the strategy
+// from its type
+func (s *sampler) keyField(model *config.ModelConfig, id *shape, where string) {
+	switch id.scalar {
+	case "int", "int64":
+		model.PrimaryKey = ""
+		if id.scalar == "int64" {
+			model.PrimaryKey = config.KeyInt64
+		}
+	case "uuid.UUID":
+		model.PrimaryKey = config.KeyUUID
+	case "string":
+		model.PrimaryKey = config.KeyComposite + "(ID)"
+		for i := range model.Fields {
+			if model.Fields[i].Name == "ID" {
+				model.Fields[i] = config.FieldConfig{Name: "ID", Type: "string", Tag: "`json:\"id\"`"}
+			}
+		}
+	default:
+		s.result.warnf("%s: unsupported id type, the default key is kept", where)
+	}
+}
+
+// object converts a nested object into an embedded struct or a belongs_to
+// model
+func (s *sampler) object(model *config.ModelConfig, key, name string, value *shape, where string) {
+	embedded := s.opts.Embedded || model.Embedded
+	nested := config.ModelConfig{Name: s.modelName(model.Name, name), Embedded: embedded}
+	if !embedded {
+		nested.Fields = config.DefaultModelFields()
+	}
+	s.fields(&nested, value, where+".")
+	nested.ApplyPrimaryKey()
+	if nested.IsCompositeKey() {
+		s.result.warnf("%s: text id cannot be referenced, stored as JSON", where)
+		s.json(model, key, name)
+		return
+	}
+	s.result.Models = append(s.result.Models, nested)
+
+	if embedded {
+		field := config.FieldConfig{Name: name, Type: nested.Name}
+		if inflect.Snake(name) != key {
+			field.JSONName = key
+		}
+		field.Tag = fmt.Sprintf("`gorm:\"embedded;embeddedPrefix:%s_\" json:\"%s\"`", inflect.Snake(name), field.JSONKey())
+		model.Fields = append(model.Fields, field)
+		return
+	}
+
+	relation := config.RelationConfig{Name: name, Kind: config.RelationBelongsTo}
+	if relation.Target() != nested.Name {
+		relation.Model = nested.Name
+	}
+	if relation.JSONKey() != key {
+		relation.JSONName = key
+	}
+	model.Relations = append(model.Relations, relation)
+}
+
+// collection converts an array of objects into a has_many model belonging
+// to the model
+func (s *sampler) collection(model *config.ModelConfig, key, name string, elem *shape, where string) {
+	if model.IsCompositeKey() {
+		s.result.warnf("%s: %s has a text id, stored as JSON", where, model.Name)
+		s.json(model, key, name)
+		return
+	}
+	nested := config.ModelConfig{
+		Name:   s.modelName(model.Name, model.Name+inflect.Singular(name)),
+		Fields: config.DefaultModelFields(),
+	}
+	nested.Relations = []config.RelationConfig{{Name: model.Name, Kind: config.RelationBelongsTo}}
+	s.fields(&nested, elem, where+"[].")
+	nested.ApplyPrimaryKey()
+	s.result.Models = append(s.result.Models, nested)
+
+	relation := config.RelationConfig{Name: name, Kind: config.RelationHasMany}
+	if relation.Target() != nested.Name {
+		relation.Model = nested.Name
+	}
+	if relation.JSONKey() != key {
+		relation.JSONName = key
+	}
+	model.Relations = append(model.Relations, relation)
+}
+
+// json adds a JSON field holding a nested value as is
+func (s *sampler) json(model *config.ModelConfig, key, name string) {
+	field := config.FieldConfig{Name: name, Type: config.FieldJSON}
+	if inflect.Snake(name) != key {
+		field.JSONName = key
+	}
+	field.Tag = fmt.Sprintf("`json:\"%s\"`", field.JSONKey())
+	model.Fields = append(model.Fields, field)
+}
+
+// modelName returns an unused model name for a nested object, prefixed
+// with its parent when the plain name is taken
+func (s *sampler) modelName(parent, name string) string {
+	name = inflect.Singular(name)
+	if s.names[name] {
+		name = parent + name
+	}
+	base := name
+	for i := 2; s.names[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	s.names[name] = true
+	return name
+}
@@ -0,0 +1,104 @@
+// Package importer reads existing database schemas, such as SQL DDL, and
+// API contracts, and converts their tables and schemas into model
+// configurations.
+package importer
+
+// Schema is the part of a database schema models are generated from
+type Schema struct {
+	Tables []*Table
+	// Enums maps enum type names to their values
+	Enums map[string][]string
+	// Domains maps domain names to their underlying type
+	Domains map[string]string
+}
+
+// Table is a database table
+type Table struct {
+	Name        string
+	Columns     []*Column
+	PrimaryKey  []string
+	Indexes     []Index
+	ForeignKeys []ForeignKey
+}
+
+// Column is a column of a table. Type is the lower case SQL type as
+// written, e.g. character varying(255) or integer[].
+type Column struct {
+	Name    string
+	Type    string
+	NotNull bool
+	// Default is the literal default value, expressions are dropped
+	Default string
+	// Identity is set for serial, identity and auto increment columns
+	Identity bool
+}
+
+// Index is an index or unique constraint of a table
+type Index struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// ForeignKey is a foreign key constraint of a table. References is empty
+// when the primary key of the referenced table is used.
+type ForeignKey struct {
+	Columns    []string
+	Table      string
+	References []string
+	// OnDelete is the upper case ON DELETE action, empty for the default
+	OnDelete string
+}
+
+// NewSchema creates an empty schema
+func NewSchema() *Schema {
+	return &Schema{
+		Enums:   map[string][]string{},
+		Domains: map[string]string{},
+	}
+}
+
+// Table returns the table with the given name
+func (s *Schema) Table(name string) *Table {
+	for _, table := range s.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// Column returns the column with the given name
+func (t *Table) Column(name string) *Column {
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column
+		}
+	}
+	return nil
+}
+
+// IsPrimaryKey reports whether the column is the whole primary key
+func (t *Table) IsPrimaryKey(column string) bool {
+	return len(t.PrimaryKey) == 1 && t.PrimaryKey[0] == column
+}
+
+// inPrimaryKey reports whether the column is part of the primary key
+func (t *Table) inPrimaryKey(column string) bool {
+	for _, name := range t.PrimaryKey {
+		if name == column {
+			return true
+		}
+	}
+	return false
+}
+
+// foreignKey returns the single column foreign key of column
+func (t *Table) foreignKey(column string) (ForeignKey, bool) {
+	for _, fk := range t.ForeignKeys {
+		if len(fk.Columns) == 1 && fk.Columns[0] == column {
+			return fk, true
+		}
+	}
+	return ForeignKey{}, false
+}
@@ -0,0 +1,739 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseSQL reads the tables, indexes, enum types and domains declared by
+// CREATE TABLE, CREATE INDEX, CREATE TYPE, CREATE DOMAIN and ALTER TABLE
+// ADD statements. Other statements, such as functions and grants in a
+// pg_dump, are skipped.
+func ParseSQL(src string) (*Schema, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := NewSchema()
+	for _, stmt := range splitStatements(tokens) {
+		p := &parser{tokens: stmt, schema: schema}
+		if err := p.statement(); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokQuoted
+	tokString
+	tokNumber
+	tokSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// lex splits DDL into tokens, dropping comments. Unquoted identifiers are
+// folded to lower case like Postgres does.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	line := 1
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			start := line
+			for i += 2; i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/'); i++ {
+				if runes[i] == '\n' {
+					line++
+				}
+			}
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("line %d: unterminated comment", start)
+			}
+			i += 2
+		case r == '\'' || r == '"' || r == '`':
+			text, n, err := quoted(runes[i:], r)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			kind := tokQuoted
+			if r == '\'' {
+				kind = tokString
+			}
+			tokens = append(tokens, token{kind: kind, text: text, line: line})
+			line += strings.Count(string(runes[i:i+n]), "\n")
+			i += n
+		case r == '$' && dollarTag(runes[i:]) != "":
+			tag := dollarTag(runes[i:])
+			rest := string(runes[i+len(tag):])
+			end := strings.Index(rest, tag)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated %s string", line, tag)
+			}
+			body := rest[:end]
+			tokens = append(tokens, token{kind: tokString, text: body, line: line})
+			line += strings.Count(body, "\n")
+			i += len([]rune(tag))*2 + len([]rune(body))
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokWord, text: strings.ToLower(string(runes[start:i])), line: line})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[start:i]), line: line})
+		case r == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			tokens = append(tokens, token{kind: tokSymbol, text: "::", line: line})
+			i += 2
+		default:
+			tokens = append(tokens, token{kind: tokSymbol, text: string(r), line: line})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// quoted reads a string or identifier quoted with q, where a doubled quote
+// escapes it, and returns its text and length in runes
+func quoted(runes []rune, q rune) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(runes); i++ {
+		if runes[i] != q {
+			b.WriteRune(runes[i])
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == q {
+			b.WriteRune(q)
+			i++
+			continue
+		}
+		return b.String(), i + 1, nil
+	}
+	return "", 0, fmt.Errorf("unterminated %c", q)
+}
+
+// dollarTag returns the $tag$ opening a dollar quoted string
+func dollarTag(runes []rune) string {
+	for i := 1; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '$':
+			return string(runes[:i+1])
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_':
+			return ""
+		}
+	}
+	return ""
+}
+
+// splitStatements splits tokens on semicolons
+func splitStatements(tokens []token) [][]token {
+	var stmts [][]token
+	start := 0
+	for i, tok := range tokens {
+		if tok.kind == tokSymbol && tok.text == ";" {
+			if i > start {
+				stmts = append(stmts, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		stmts = append(stmts, tokens[start:])
+	}
+	return stmts
+}
+
+// parser reads a single statement
+type parser struct {
+	tokens []token
+	pos    int
+	schema *Schema
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: tokSymbol}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+// is reports whether the next tokens are the given keywords
+func (p *parser) is(words ...string) bool {
+	for i, word := range words {
+		if p.pos+i >= len(p.tokens) {
+			return false
+		}
+		tok := p.tokens[p.pos+i]
+		if tok.kind != tokWord || tok.text != word {
+			return false
+		}
+	}
+	return true
+}
+
+// accept consumes the given keywords if they are next
+func (p *parser) accept(words ...string) bool {
+	if !p.is(words...) {
+		return false
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *parser) isSymbol(s string) bool {
+	tok := p.peek()
+	return !p.done() && tok.kind == tokSymbol && tok.text == s
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	line := 0
+	if len(p.tokens) > 0 {
+		line = p.tokens[0].line
+		if !p.done() {
+			line = p.peek().line
+		}
+	}
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// ident reads an identifier
+func (p *parser) ident() (string, error) {
+	tok := p.peek()
+	if p.done() || (tok.kind != tokWord && tok.kind != tokQuoted) {
+		return "", p.errorf("expected identifier, found %q", tok.text)
+	}
+	p.pos++
+	return tok.text, nil
+}
+
+// name reads a possibly schema qualified name and returns its last part
+func (p *parser) name() (string, error) {
+	name, err := p.ident()
+	for err == nil && p.isSymbol(".") {
+		p.pos++
+		name, err = p.ident()
+	}
+	return name, err
+}
+
+// identList reads a parenthesized list of column names
+func (p *parser) identList() ([]string, error) {
+	if !p.isSymbol("(") {
+		return nil, p.errorf("expected (")
+	}
+	group := p.group()
+	var names []string
+	for _, part := range splitList(group) {
+		if len(part) == 0 || (part[0].kind != tokWord && part[0].kind != tokQuoted) {
+			return nil, p.errorf("expected column name")
+		}
+		names = append(names, part[0].text)
+	}
+	return names, nil
+}
+
+// group consumes a parenthesized group and returns the tokens inside
+func (p *parser) group() []token {
+	start := p.pos + 1
+	depth := 0
+	for !p.done() {
+		tok := p.next()
+		if tok.kind != tokSymbol {
+			continue
+		}
+		switch tok.text {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+			if depth == 0 {
+				return p.tokens[start : p.pos-1]
+			}
+		}
+	}
+	return p.tokens[start:]
+}
+
+// skip consumes the next token, or the whole group it opens
+func (p *parser) skip() {
+	if p.isSymbol("(") {
+		p.group()
+		return
+	}
+	p.pos++
+}
+
+// splitList splits tokens on commas outside parentheses
+func splitList(tokens []token) [][]token {
+	var parts [][]token
+	depth, start := 0, 0
+	for i, tok := range tokens {
+		if tok.kind != tokSymbol {
+			continue
+		}
+		switch tok.text {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+		case ",":
+			if depth == 0 {
+				parts = append(parts, tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(tokens) {
+		parts = append(parts, tokens[start:])
+	}
+	return parts
+}
+
+func (p *parser) statement() error {
+	switch {
+	case p.accept("create"):
+		p.accept("or", "replace")
+		for p.accept("unlogged") || p.accept("temporary") || p.accept("temp") || p.accept("global") || p.accept("local") {
+		}
+		switch {
+		case p.accept("table"):
+			return p.createTable()
+		case p.accept("type"):
+			return p.createType()
+		case p.accept("domain"):
+			return p.createDomain()
+		case p.accept("unique", "index"):
+			return p.createIndex(true)
+		case p.accept("index"):
+			return p.createIndex(false)
+		}
+	case p.accept("alter", "table"):
+		return p.alterTable()
+	}
+	return nil
+}
+
+func (p *parser) createTable() error {
+	p.accept("if", "not", "exists")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if !p.isSymbol("(") {
+		// CREATE TABLE ... AS SELECT and partitions carry no columns
+		return nil
+	}
+
+	table := p.schema.Table(name)
+	if table == nil {
+		table = &Table{Name: name}
+		p.schema.Tables = append(p.schema.Tables, table)
+	}
+
+	for _, element := range splitList(p.group()) {
+		sub := &parser{tokens: element, schema: p.schema}
+		if err := sub.tableElement(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableElement reads a column definition or table constraint
+func (p *parser) tableElement(table *Table) error {
+	if p.done() {
+		return nil
+	}
+	switch {
+	case p.is("constraint"), p.is("primary", "key"), p.is("foreign", "key"), p.is("unique"),
+		p.is("check"), p.is("exclude"), p.is("like"), p.is("key"), p.is("index"), p.is("fulltext"):
+		return p.tableConstraint(table)
+	}
+	return p.column(table)
+}
+
+// tableConstraint reads a table constraint, also used by ALTER TABLE ADD
+func (p *parser) tableConstraint(table *Table) error {
+	name := ""
+	if p.accept("constraint") {
+		var err error
+		if name, err = p.ident(); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case p.accept("primary", "key"):
+		columns, err := p.identList()
+		if err != nil {
+			return err
+		}
+		table.PrimaryKey = columns
+	case p.accept("foreign", "key"):
+		columns, err := p.identList()
+		if err != nil {
+			return err
+		}
+		fk, err := p.references(columns)
+		if err != nil {
+			return err
+		}
+		table.ForeignKeys = append(table.ForeignKeys, fk)
+	case p.accept("unique"):
+		// MySQL writes UNIQUE KEY name (columns)
+		if p.accept("key") || p.accept("index") {
+			if !p.isSymbol("(") {
+				name, _ = p.ident()
+			}
+		}
+		p.accept("nulls", "not", "distinct")
+		columns, err := p.identList()
+		if err != nil {
+			return err
+		}
+		table.Indexes = append(table.Indexes, Index{Name: name, Columns: columns, Unique: true})
+	case p.accept("key"), p.accept("index"):
+		if !p.isSymbol("(") {
+			name, _ = p.ident()
+		}
+		columns, err := p.identList()
+		if err != nil {
+			return err
+		}
+		table.Indexes = append(table.Indexes, Index{Name: name, Columns: columns})
+	}
+	return nil
+}
+
+// references reads a REFERENCES clause for the given columns
+func (p *parser) references(columns []string) (ForeignKey, error) {
+	if !p.accept("references") {
+		return ForeignKey{}, p.errorf("expected REFERENCES")
+	}
+	target, err := p.name()
+	if err != nil {
+		return ForeignKey{}, err
+	}
+	fk := ForeignKey{Columns: columns, Table: target}
+	if p.isSymbol("(") {
+		if fk.References, err = p.identList(); err != nil {
+			return ForeignKey{}, err
+		}
+	}
+	for p.accept("on") {
+		switch {
+		case p.accept("delete"):
+			fk.OnDelete = p.referentialAction()
+		case p.accept("update"):
+			p.referentialAction()
+		}
+	}
+	return fk, nil
+}
+
+// referentialAction reads the action of an ON DELETE or ON UPDATE clause.
+// NO ACTION is the default and reads as empty.
+func (p *parser) referentialAction() string {
+	if p.accept("no", "action") {
+		return ""
+	}
+	for _, words := range [][]string{{"cascade"}, {"restrict"}, {"set", "null"}, {"set", "default"}} {
+		if p.accept(words...) {
+			return strings.ToUpper(strings.Join(words, " "))
+		}
+	}
+	return ""
+}
+
+// columnConstraints are the keywords ending the type of a column
+var columnConstraints = map[string]bool{
+	"constraint": true, "not": true, "null": true, "default": true, "primary": true,
+	"unique": true, "references": true, "check": true, "collate": true, "generated": true,
+	"auto_increment": true, "autoincrement": true, "comment": true, "on": true,
+}
+
+// column reads a column definition
+func (p *parser) column(table *Table) error {
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	column := &Column{Name: name, Type: p.typeName()}
+	switch strings.TrimSuffix(column.Type, "[]") {
+	case "serial", "serial4", "bigserial", "serial8", "smallserial", "serial2":
+		column.Identity = true
+	}
+
+	for !p.done() {
+		switch {
+		case p.accept("constraint"):
+			p.skip()
+		case p.accept("not", "null"):
+			column.NotNull = true
+		case p.accept("null"):
+		case p.accept("default"):
+			column.Default = p.defaultValue(column)
+		case p.accept("primary", "key"):
+			table.PrimaryKey = []string{name}
+		case p.accept("unique"):
+			p.accept("key")
+			table.Indexes = append(table.Indexes, Index{Columns: []string{name}, Unique: true})
+		case p.is("references"):
+			fk, err := p.references([]string{name})
+			if err != nil {
+				return err
+			}
+			table.ForeignKeys = append(table.ForeignKeys, fk)
+		case p.accept("generated"):
+			for !p.done() && !p.is("identity") && !p.isSymbol("(") {
+				p.pos++
+			}
+			if p.accept("identity") {
+				column.Identity = true
+			}
+		case p.accept("auto_increment"), p.accept("autoincrement"):
+			column.Identity = true
+		default:
+			p.skip()
+		}
+	}
+
+	table.Columns = append(table.Columns, column)
+	return nil
+}
+
+// typeName reads a column type up to its first constraint, normalizing the
+// spacing to e.g. character varying(255) or timestamp(6) with time zone
+func (p *parser) typeName() string {
+	var b strings.Builder
+	depth := 0
+	for !p.done() {
+		tok := p.peek()
+		if depth == 0 && tok.kind == tokWord && columnConstraints[tok.text] {
+			break
+		}
+		p.pos++
+
+		switch {
+		case tok.kind == tokSymbol && (tok.text == "(" || tok.text == "["):
+			depth++
+			b.WriteString(tok.text)
+		case tok.kind == tokSymbol && (tok.text == ")" || tok.text == "]"):
+			depth--
+			b.WriteString(tok.text)
+		case tok.kind == tokSymbol && tok.text == ".":
+			// Drop the schema of qualified types
+			b.Reset()
+		case tok.kind == tokSymbol:
+			b.WriteString(tok.text)
+		default:
+			s := b.String()
+			if s != "" && depth == 0 && !strings.HasSuffix(s, "(") {
+				b.WriteString(" ")
+			}
+			b.WriteString(strings.ToLower(tok.text))
+		}
+	}
+	return b.String()
+}
+
+// defaultValue reads a DEFAULT expression and returns it if it is a
+// literal. nextval() defaults mark the column as identity.
+func (p *parser) defaultValue(column *Column) string {
+	start := p.pos
+	depth := 0
+	for !p.done() {
+		tok := p.peek()
+		if depth == 0 && tok.kind == tokWord && columnConstraints[tok.text] && tok.text != "null" {
+			break
+		}
+		if tok.kind == tokSymbol {
+			switch tok.text {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+		}
+		p.pos++
+	}
+
+	expr := p.tokens[start:p.pos]
+	// Casts such as 'draft'::post_status keep their literal
+	for i, tok := range expr {
+		if tok.kind == tokSymbol && tok.text == "::" {
+			expr = expr[:i]
+			break
+		}
+	}
+	if len(expr) == 2 && expr[0].kind == tokSymbol && expr[0].text == "(" {
+		expr = expr[1:]
+	}
+	if len(expr) >= 2 && expr[0].kind == tokSymbol && expr[0].text == "(" && expr[len(expr)-1].text == ")" {
+		expr = expr[1 : len(expr)-1]
+	}
+
+	switch {
+	case len(expr) == 1 && (expr[0].kind == tokString || expr[0].kind == tokNumber):
+		return expr[0].text
+	case len(expr) == 1 && expr[0].kind == tokWord && (expr[0].text == "true" || expr[0].text == "false"):
+		return expr[0].text
+	case len(expr) == 2 && expr[0].text == "-" && expr[1].kind == tokNumber:
+		return "-" + expr[1].text
+	case len(expr) > 0 && expr[0].kind == tokWord && expr[0].text == "nextval":
+		column.Identity = true
+	}
+	return ""
+}
+
+func (p *parser) createType() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if !p.accept("as", "enum") || !p.isSymbol("(") {
+		return nil
+	}
+	var values []string
+	for _, tok := range p.group() {
+		if tok.kind == tokString {
+			values = append(values, tok.text)
+		}
+	}
+	p.schema.Enums[name] = values
+	return nil
+}
+
+func (p *parser) createDomain() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	p.accept("as")
+	p.schema.Domains[name] = p.typeName()
+	return nil
+}
+
+func (p *parser) createIndex(unique bool) error {
+	p.accept("concurrently")
+	p.accept("if", "not", "exists")
+	name := ""
+	if !p.is("on") {
+		var err error
+		if name, err = p.name(); err != nil {
+			return err
+		}
+	}
+	if !p.accept("on") {
+		return p.errorf("expected ON")
+	}
+	p.accept("only")
+	tableName, err := p.name()
+	if err != nil {
+		return err
+	}
+	if p.accept("using") {
+		p.pos++
+	}
+	if !p.isSymbol("(") {
+		return p.errorf("expected index columns")
+	}
+
+	var columns []string
+	for _, part := range splitList(p.group()) {
+		// Expression indexes cannot be expressed as GORM tags
+		if len(part) == 0 || (part[0].kind != tokWord && part[0].kind != tokQuoted) || (len(part) > 1 && part[1].kind == tokSymbol) {
+			return nil
+		}
+		columns = append(columns, part[0].text)
+	}
+	// Partial indexes only hold for some rows
+	for !p.done() {
+		if p.accept("where") {
+			return nil
+		}
+		p.pos++
+	}
+
+	if table := p.schema.Table(tableName); table != nil {
+		table.Indexes = append(table.Indexes, Index{Name: name, Columns: columns, Unique: unique})
+	}
+	return nil
+}
+
+func (p *parser) alterTable() error {
+	p.accept("if", "exists")
+	p.accept("only")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	table := p.schema.Table(name)
+	if table == nil {
+		return nil
+	}
+
+	for _, action := range splitList(p.tokens[p.pos:]) {
+		sub := &parser{tokens: action, schema: p.schema}
+		if !sub.accept("add") {
+			continue
+		}
+		if sub.accept("column") {
+			sub.accept("if", "not", "exists")
+			if err := sub.column(table); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := sub.tableElement(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDefault returns the literal of a default expression read from a
+// database catalog, marking nextval() defaults as identity
+func parseDefault(expr string, column *Column) string {
+	tokens, err := lex(expr)
+	if err != nil {
+		return ""
+	}
+	p := &parser{tokens: tokens}
+	return p.defaultValue(column)
+}
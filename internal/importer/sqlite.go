@@ -0,0 +1,224 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// introspectSQLite reads the schema of a SQLite database from
+// sqlite_master and the table_info, index_list and foreign_key_list pragmas
+func introspectSQLite(ctx context.Context, db *sql.DB) (*Schema, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY rowid`)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	schema := NewSchema()
+	for _, name := range names {
+		table := &Table{Name: name}
+		if err := sqliteColumns(ctx, db, table); err != nil {
+			return nil, err
+		}
+		if err := sqliteIndexes(ctx, db, table); err != nil {
+			return nil, err
+		}
+		if err := sqliteForeignKeys(ctx, db, table); err != nil {
+			return nil, err
+		}
+		schema.Tables = append(schema.Tables, table)
+	}
+	return schema, nil
+}
+
+// pragma runs a table pragma, quoting the table name
+func pragma(ctx context.Context, db *sql.DB, name, arg string) (*sql.Rows, error) {
+	return db.QueryContext(ctx, "PRAGMA "+name+"(\""+strings.ReplaceAll(arg, `"`, `""`)+"\")")
+}
+
+func sqliteColumns(ctx context.Context, db *sql.DB, table *Table) error {
+	rows, err := pragma(ctx, db, "table_info", table.Name)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var keys []struct {
+		column   string
+		position int
+	}
+	for rows.Next() {
+		var (
+			cid      int
+			name     string
+			typ      string
+			notNull  bool
+			dflt     sql.NullString
+			position int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &position); err != nil {
+			return err
+		}
+
+		column := &Column{Name: name, Type: strings.ToLower(strings.TrimSpace(typ)), NotNull: notNull}
+		// SQLite stores REAL values as 8 byte floats
+		if column.Type == "real" {
+			column.Type = "double precision"
+		}
+		if dflt.Valid {
+			column.Default = parseDefault(dflt.String, column)
+		}
+		if position > 0 {
+			keys = append(keys, struct {
+				column   string
+				position int
+			}{name, position})
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	table.PrimaryKey = make([]string, len(keys))
+	for _, key := range keys {
+		table.PrimaryKey[key.position-1] = key.column
+	}
+	// An INTEGER PRIMARY KEY is an alias of the auto incremented rowid
+	if len(keys) == 1 {
+		if column := table.Column(keys[0].column); column.Type == "integer" {
+			column.Identity = true
+		}
+	}
+	return nil
+}
+
+func sqliteIndexes(ctx context.Context, db *sql.DB, table *Table) error {
+	rows, err := pragma(ctx, db, "index_list", table.Name)
+	if err != nil {
+		return err
+	}
+
+	var indexes []Index
+	for rows.Next() {
+		var (
+			seq     int
+			name    string
+			unique  bool
+			origin  string
+			partial bool
+		)
+		if err := rows.Scan(&seq, &name, &unique, &origin, &partial); err != nil {
+			rows.Close()
+			return err
+		}
+		// The primary key is already known and partial indexes only
+		// hold for some rows
+		if origin != "pk" && !partial {
+			indexes = append(indexes, Index{Name: name, Unique: unique})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, index := range indexes {
+		columns, err := sqliteIndexColumns(ctx, db, index.Name)
+		if err != nil {
+			return err
+		}
+		if columns == nil {
+			continue
+		}
+		index.Columns = columns
+		// UNIQUE constraints are backed by indexes named sqlite_autoindex_*
+		if strings.HasPrefix(index.Name, "sqlite_autoindex_") {
+			index.Name = ""
+		}
+		table.Indexes = append(table.Indexes, index)
+	}
+	return nil
+}
+
+// sqliteIndexColumns returns the columns of an index in order, or nil for
+// indexes on expressions
+func sqliteIndexColumns(ctx context.Context, db *sql.DB, index string) ([]string, error) {
+	rows, err := pragma(ctx, db, "index_info", index)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	expression := false
+	for rows.Next() {
+		var (
+			seqno int
+			cid   int
+			name  sql.NullString
+		)
+		if err := rows.Scan(&seqno, &cid, &name); err != nil {
+			return nil, err
+		}
+		if !name.Valid {
+			expression = true
+		}
+		columns = append(columns, name.String)
+	}
+	if expression {
+		return nil, rows.Err()
+	}
+	return columns, rows.Err()
+}
+
+func sqliteForeignKeys(ctx context.Context, db *sql.DB, table *Table) error {
+	rows, err := pragma(ctx, db, "foreign_key_list", table.Name)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byID := map[int]int{}
+	for rows.Next() {
+		var (
+			id, seq                   int
+			target, from              string
+			to                        sql.NullString
+			onUpdate, onDelete, match string
+		)
+		if err := rows.Scan(&id, &seq, &target, &from, &to, &onUpdate, &onDelete, &match); err != nil {
+			return err
+		}
+
+		i, ok := byID[id]
+		if !ok {
+			i = len(table.ForeignKeys)
+			byID[id] = i
+			table.ForeignKeys = append(table.ForeignKeys, ForeignKey{Table: target})
+			if onDelete != "NO ACTION" {
+				table.ForeignKeys[i].OnDelete = onDelete
+			}
+		}
+		fk := &table.ForeignKeys[i]
+		fk.Columns = append(fk.Columns, from)
+		// References to the primary key leave the target column out
+		if to.Valid {
+			fk.References = append(fk.References, to.String)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,343 @@
+// Package inflect converts identifiers between naming conventions and
+// between singular and plural English forms.
+package inflect
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// initialisms are the words Go style writes in all caps
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true,
+	"DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "JWT": true,
+	"LHS": true, "QPS": true, "RAM": true, "RHS": true, "RPC": true,
+	"SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "UID": true,
+	"ULID": true, "UUID": true, "URI": true, "URL": true, "UTF8": true,
+	"VM": true, "XML": true, "XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// initialismPlurals maps plural forms such as "IDs" to their initialism
+var initialismPlurals = map[string]string{}
+
+func init() {
+	for word := range initialisms {
+		initialismPlurals[word+"S"] = word
+	}
+}
+
+// Words splits an identifier into its words. Underscores, hyphens, spaces
+// and case changes all separate words, and runs of capitals are kept
+// together, so "HTTPServer" yields "HTTP" and "Server".
+func Words(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+
+	flush := func(end int) {
+		if start >= 0 && end > start {
+			words = append(words, string(runes[start:end]))
+		}
+		start = -1
+	}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+
+		prev := runes[i-1]
+		switch {
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			// fooBar
+			flush(i)
+			start = i
+		case unicode.IsUpper(r) && unicode.IsDigit(prev):
+			// utf8Reader
+			flush(i)
+			start = i
+		case unicode.IsLower(r) && unicode.IsUpper(prev) && i-1 > start:
+			// HTTPServer splits before the last capital, except for
+			// pluralised initialisms such as IDs
+			if r == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1])) {
+				continue
+			}
+			flush(i - 1)
+			start = i - 1
+		}
+	}
+	flush(len(runes))
+
+	return words
+}
+
+// Pascal converts an identifier to PascalCase with Go initialisms, so
+// "user_id" becomes "UserID". Words in capitals are kept as acronyms, so
+// "SKU" stays "SKU", unless the whole identifier is in capitals with
+// separators, as in "API_KEY".
+func Pascal(s string) string {
+	var b strings.Builder
+	for _, word := range caseWords(s) {
+		b.WriteString(pascalWord(word))
+	}
+	return b.String()
+}
+
+// Camel converts an identifier to camelCase with Go initialisms, so
+// "UserID" becomes "userID" and "URLPath" becomes "urlPath"
+func Camel(s string) string {
+	words := caseWords(s)
+	if len(words) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.ToLower(words[0]))
+	for _, word := range words[1:] {
+		b.WriteString(pascalWord(word))
+	}
+	return b.String()
+}
+
+// caseWords returns the words of an identifier for Pascal and Camel,
+// lower casing those of identifiers such as API_KEY that are in capitals
+// throughout
+func caseWords(s string) []string {
+	words := Words(s)
+	if strings.ContainsAny(s, "_- ") && s == strings.ToUpper(s) {
+		for i, word := range words {
+			words[i] = strings.ToLower(word)
+		}
+	}
+	return words
+}
+
+// Snake converts an identifier to snake_case, so "OrderItem" becomes
+// "order_item" and "UserID" becomes "user_id"
+func Snake(s string) string {
+	return joinLower(s, "_")
+}
+
+// Kebab converts an identifier to kebab-case, so "OrderItem" becomes
+// "order-item"
+func Kebab(s string) string {
+	return joinLower(s, "-")
+}
+
+// Humanize converts an identifier to lower case words for use in prose,
+// keeping initialisms, so "OrderItem" becomes "order item"
+func Humanize(s string) string {
+	words := Words(s)
+	for i, word := range words {
+		if !initialisms[strings.ToUpper(word)] && !isAcronym(word) {
+			words[i] = strings.ToLower(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// isAcronym reports whether a word is written in capitals, such as SKU, or
+// is the plural of such a word, such as SKUs
+func isAcronym(word string) bool {
+	word = strings.TrimSuffix(word, "s")
+	return len(word) > 1 && word == strings.ToUpper(word) && word != strings.ToLower(word)
+}
+
+func joinLower(s, sep string) string {
+	words := Words(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, sep)
+}
+
+func pascalWord(word string) string {
+	upper := strings.ToUpper(word)
+	if initialisms[upper] {
+		return upper
+	}
+	if base, ok := initialismPlurals[upper]; ok {
+		return base + "s"
+	}
+	if isAcronym(word) {
+		return word
+	}
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+func rules(pairs ...string) []rule {
+	result := make([]rule, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		result = append(result, rule{regexp.MustCompile("(?i)" + pairs[i]), pairs[i+1]})
+	}
+	return result
+}
+
+// Rules are tried in order, the first match wins
+var pluralRules = rules(
+	`(quiz)$`, "${1}zes",
+	`^(ox)$`, "${1}en",
+	`([m|l])ouse$`, "${1}ice",
+	`(matr|vert|ind)(?:ix|ex)$`, "${1}ices",
+	`(x|ch|ss|sh|zz)$`, "${1}es",
+	`([^aeiouy]|qu)y$`, "${1}ies",
+	`(hive)$`, "${1}s",
+	`(?:([^f])fe|([lr])f)$`, "${1}${2}ves",
+	`sis$`, "ses",
+	`([ti])um$`, "${1}a",
+	`(buffal|tomat|potat|her|ech)o$`, "${1}oes",
+	`(bu|alia|statu|campu|censu)s$`, "${1}ses",
+	`(octop)us$`, "${1}i",
+	`(ax|test)is$`, "${1}es",
+	`us$`, "uses",
+	`s$`, "s",
+	`$`, "s",
+)
+
+var singularRules = rules(
+	`(quiz)zes$`, "${1}",
+	`(matr)ices$`, "${1}ix",
+	`(vert|ind)ices$`, "${1}ex",
+	`^(ox)en`, "${1}",
+	`(alias|status|bus|bonus|campus|census|corpus|focus|radius|virus|apparatus|syllabus|stimulus|consensus)(es)?$`, "${1}",
+	`(octop|vir)(us|i)$`, "${1}us",
+	`(us)es$`, "${1}",
+	`^(a)x[ie]s$`, "${1}xis",
+	`(cris|test)(is|es)$`, "${1}is",
+	`(shoe)s$`, "${1}",
+	`(o)es$`, "${1}",
+	`(x|ch|ss|sh|zz)es$`, "${1}",
+	`([m|l])ice$`, "${1}ouse",
+	`(movie)s$`, "${1}",
+	`([^aeiouy]|qu)ies$`, "${1}y",
+	`([lr])ves$`, "${1}f",
+	`(tive)s$`, "${1}",
+	`(hive)s$`, "${1}",
+	`([^f])ves$`, "${1}fe",
+	`(analy|ba|diagno|parenthe|progno|synop|the)ses$`, "${1}sis",
+	`([ti])a$`, "${1}um",
+	`(n)ews$`, "${1}ews",
+	// Words ending in ss or sis are singular, unlike menus or emus
+	`(ss|sis)$`, "${1}",
+	`s$`, "",
+)
+
+var irregulars = map[string]string{
+	"person":    "people",
+	"man":       "men",
+	"woman":     "women",
+	"child":     "children",
+	"tooth":     "teeth",
+	"foot":      "feet",
+	"goose":     "geese",
+	"sex":       "sexes",
+	"move":      "moves",
+	"cactus":    "cacti",
+	"criterion": "criteria",
+	"leaf":      "leaves",
+	"loaf":      "loaves",
+	"thief":     "thieves",
+	"sheaf":     "sheaves",
+}
+
+var uncountables = map[string]bool{
+	"equipment": true, "information": true, "rice": true, "money": true,
+	"species": true, "series": true, "fish": true, "sheep": true,
+	"deer": true, "news": true, "metadata": true, "feedback": true,
+	"data": true, "media": true, "staff": true, "software": true,
+	"sms": true,
+}
+
+// Plural returns the English plural of an identifier. Only the last word
+// is inflected and its case is preserved, so "OrderItem" becomes
+// "OrderItems" and "Category" becomes "Categories".
+func Plural(s string) string {
+	return inflectLast(s, func(word string) string {
+		lower := strings.ToLower(word)
+		if uncountables[lower] {
+			return word
+		}
+		if initialisms[strings.ToUpper(word)] || isAcronym(word) {
+			return word + "s"
+		}
+		if plural, ok := irregulars[lower]; ok {
+			return plural
+		}
+		for _, plural := range irregulars {
+			if plural == lower {
+				return word
+			}
+		}
+		return apply(pluralRules, word)
+	})
+}
+
+// Singular returns the English singular of an identifier, the inverse of
+// Plural
+func Singular(s string) string {
+	return inflectLast(s, func(word string) string {
+		lower := strings.ToLower(word)
+		if uncountables[lower] {
+			return word
+		}
+		if base, ok := initialismPlurals[strings.ToUpper(word)]; ok {
+			return word[:len(base)]
+		}
+		for singular, plural := range irregulars {
+			if plural == lower {
+				return singular
+			}
+		}
+		if _, ok := irregulars[lower]; ok {
+			return word
+		}
+		return apply(singularRules, word)
+	})
+}
+
+// inflectLast applies fn to the last word of s, keeping the separators
+// and the case of the first letter of that word. Words in capitals stay in
+// capitals unless fn only appended a suffix, as for the plural of SKU.
+func inflectLast(s string, fn func(string) string) string {
+	words := Words(s)
+	if len(words) == 0 {
+		return s
+	}
+	last := words[len(words)-1]
+	i := strings.LastIndex(s, last)
+	inflected := fn(last)
+
+	switch {
+	case last == strings.ToUpper(last) && len(last) > 1 && !initialisms[last] && !strings.HasPrefix(inflected, last):
+		inflected = strings.ToUpper(inflected)
+	case unicode.IsUpper([]rune(last)[0]):
+		runes := []rune(inflected)
+		runes[0] = unicode.ToUpper(runes[0])
+		inflected = string(runes)
+	}
+	return s[:i] + inflected + s[i+len(last):]
+}
+
+func apply(rules []rule, word string) string {
+	for _, r := range rules {
+		if r.pattern.MatchString(word) {
+			return r.pattern.ReplaceAllString(word, r.replacement)
+		}
+	}
+	return word
+}
@@ -0,0 +1,117 @@
+package inflect
+
+import "testing"
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"product", "products"},
+		{"OrderItem", "OrderItems"},
+		{"Category", "Categories"},
+		{"box", "boxes"},
+		{"class", "classes"},
+		{"status", "statuses"},
+		{"bonus", "bonuses"},
+		{"menu", "menus"},
+		{"leaf", "leaves"},
+		{"knife", "knives"},
+		{"shelf", "shelves"},
+		{"person", "people"},
+		{"people", "people"},
+		{"analysis", "analyses"},
+		{"news", "news"},
+		{"Sms", "Sms"},
+		{"ID", "IDs"},
+		{"SKU", "SKUs"},
+		{"ProductSKU", "ProductSKUs"},
+		{"order_item", "order_items"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Plural(tt.in); got != tt.want {
+			t.Errorf("Plural(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"products", "product"},
+		{"OrderItems", "OrderItem"},
+		{"categories", "category"},
+		{"boxes", "box"},
+		{"classes", "class"},
+		{"class", "class"},
+		{"statuses", "status"},
+		{"status", "status"},
+		{"bonus", "bonus"},
+		{"menus", "menu"},
+		{"Menus", "Menu"},
+		{"emus", "emu"},
+		{"leaves", "leaf"},
+		{"knives", "knife"},
+		{"shelves", "shelf"},
+		{"thieves", "thief"},
+		{"people", "person"},
+		{"analyses", "analysis"},
+		{"viruses", "virus"},
+		{"news", "news"},
+		{"Sms", "Sms"},
+		{"IDs", "ID"},
+		{"SKUs", "SKU"},
+		{"ORDERS", "ORDER"},
+		{"order_items", "order_item"},
+	}
+	for _, tt := range tests {
+		if got := Singular(tt.in); got != tt.want {
+			t.Errorf("Singular(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSnake(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"OrderItem", "order_item"},
+		{"UserID", "user_id"},
+		{"HTTPServer", "http_server"},
+		{"TagIDs", "tag_ids"},
+		{"utf8Reader", "utf8_reader"},
+		{"SKU", "sku"},
+		{"order-item", "order_item"},
+		{"Some Col", "some_col"},
+		{"already_snake", "already_snake"},
+	}
+	for _, tt := range tests {
+		if got := Snake(tt.in); got != tt.want {
+			t.Errorf("Snake(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPascal(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"user_id", "UserID"},
+		{"order_item", "OrderItem"},
+		{"orderItem", "OrderItem"},
+		{"tag_ids", "TagIDs"},
+		{"api_url", "APIURL"},
+		{"SKU", "SKU"},
+		{"SKUs", "SKUs"},
+		{"product_SKU", "ProductSKU"},
+		{"API_KEY", "APIKey"},
+		{"Some Col", "SomeCol"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Pascal(tt.in); got != tt.want {
+			t.Errorf("Pascal(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/spec"
+)
+
+// FileName is the name of the project manifest written by generate
+const FileName = ".hexa.yaml"
+
+const header = "# Project manifest maintained by hexa-go. It uses the same format as\n" +
+	"# `hexa-go generate --from` and is updated by every `hexa-go add` command.\n"
+
+// Path returns the manifest location inside a project directory
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// Exists reports whether a project directory has a manifest
+func Exists(dir string) bool {
+	_, err := os.Stat(Path(dir))
+	return err == nil
+}
+
+// Load reads the manifest of the project in dir
+func Load(dir string) (config.ProjectConfig, error) {
+	projectConfig, err := spec.Load(Path(dir))
+	if err != nil {
+		return projectConfig, err
+	}
+	projectConfig.Dir = dir
+	return projectConfig, nil
+}
+
+// Marshal encodes a project configuration as manifest contents
+func Marshal(projectConfig config.ProjectConfig) ([]byte, error) {
+	data, err := spec.Marshal(projectConfig)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(header), data...), nil
+}
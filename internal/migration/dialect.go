@@ -0,0 +1,242 @@
+package migration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+)
+
+// Dialect is the SQL dialect migrations are written in
+type Dialect string
+
+// Supported dialects, named like the databases of config.Databases
+const (
+	Postgres  Dialect = config.DatabasePostgres
+	MySQL     Dialect = config.DatabaseMySQL
+	SQLite    Dialect = config.DatabaseSQLite
+	SQLServer Dialect = config.DatabaseSQLServer
+)
+
+// DialectOf returns the dialect of the database of a project
+func DialectOf(project config.ProjectConfig) Dialect {
+	return Dialect(project.DB())
+}
+
+// quote quotes an identifier
+func (d Dialect) quote(name string) string {
+	switch d {
+	case MySQL:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case SQLServer:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// columnType returns the column type of a Go type, honouring an explicit
+// GORM type option. Integer primary keys are auto incremented.
+func (d Dialect) columnType(goType string, options map[string]string, key, autoIncrement bool) string {
+	if t, ok := options["type"]; ok && t != "" {
+		return t
+	}
+	if _, ok := options["serializer"]; ok {
+		return d.textType(0, false)
+	}
+	goType = strings.TrimPrefix(goType, "*")
+	if strings.HasPrefix(goType, "[]") && goType != "[]byte" || strings.HasPrefix(goType, "map[") {
+		return d.textType(0, false)
+	}
+
+	if bits, ok := integerBits(goType); ok {
+		return d.integerType(bits, autoIncrement)
+	}
+
+	switch goType {
+	case "bool", "sql.NullBool":
+		if d == SQLServer {
+			return "bit"
+		}
+		return "boolean"
+	case "string", "sql.NullString":
+		size, _ := strconv.Atoi(options["size"])
+		return d.textType(size, key || isIndexed(options))
+	case "float32":
+		if d == MySQL {
+			return "float"
+		}
+		return "real"
+	case "float64", "sql.NullFloat64":
+		switch d {
+		case MySQL:
+			return "double"
+		case SQLite:
+			return "real"
+		case SQLServer:
+			return "float"
+		}
+		return "double precision"
+	case "time.Time", "gorm.DeletedAt", "sql.NullTime":
+		switch d {
+		case MySQL:
+			return "datetime(3)"
+		case SQLite:
+			return "datetime"
+		case SQLServer:
+			return "datetimeoffset"
+		}
+		return "timestamptz"
+	case "uuid.UUID":
+		if d == Postgres {
+			return "uuid"
+		}
+		return "char(36)"
+	case "[]byte":
+		switch d {
+		case MySQL:
+			return "longblob"
+		case SQLite:
+			return "blob"
+		case SQLServer:
+			return "varbinary(max)"
+		}
+		return "bytea"
+	case "json.RawMessage", "datatypes.JSON":
+		switch d {
+		case MySQL:
+			return "json"
+		case Postgres:
+			return "jsonb"
+		}
+		return d.textType(0, false)
+	case "decimal.Decimal":
+		return "numeric"
+	}
+	// Other types, such as enums, are stored as text
+	return d.textType(0, key || isIndexed(options))
+}
+
+// textType returns the type of a string column. MySQL and SQL Server
+// cannot index unbounded text, and MySQL cannot give it a default either,
+// so such columns get a bounded size.
+func (d Dialect) textType(size int, bounded bool) string {
+	switch d {
+	case MySQL:
+		switch {
+		case size > 0:
+			return fmt.Sprintf("varchar(%d)", size)
+		case bounded:
+			return "varchar(191)"
+		}
+		return "longtext"
+	case SQLServer:
+		switch {
+		case size > 0 && size <= 4000:
+			return fmt.Sprintf("nvarchar(%d)", size)
+		case bounded:
+			return "nvarchar(450)"
+		}
+		return "nvarchar(max)"
+	case SQLite:
+		return "text"
+	}
+	if size > 0 {
+		return fmt.Sprintf("varchar(%d)", size)
+	}
+	return "text"
+}
+
+// integerType returns the type of an integer column of the given size.
+// SQLite auto increments an integer primary key by itself.
+func (d Dialect) integerType(bits int, autoIncrement bool) string {
+	if d == SQLite {
+		return "integer"
+	}
+
+	t := "bigint"
+	switch {
+	case bits <= 16:
+		t = "smallint"
+	case bits <= 32 && d == MySQL:
+		t = "int"
+	case bits <= 32:
+		t = "integer"
+	}
+	if d == SQLServer && t == "integer" {
+		t = "int"
+	}
+	if !autoIncrement {
+		return t
+	}
+
+	switch d {
+	case MySQL:
+		return t + " AUTO_INCREMENT"
+	case SQLServer:
+		return t + " IDENTITY(1,1)"
+	}
+	if bits <= 32 {
+		return "serial"
+	}
+	return "bigserial"
+}
+
+// referenceType returns the type of a column referencing a key column of
+// the given type, without its auto increment
+func (d Dialect) referenceType(keyType string) string {
+	switch keyType {
+	case "":
+		return d.integerType(64, false)
+	case "bigserial":
+		return "bigint"
+	case "serial":
+		return "integer"
+	}
+	keyType = strings.TrimSuffix(keyType, " AUTO_INCREMENT")
+	return strings.TrimSuffix(keyType, " IDENTITY(1,1)")
+}
+
+// literal returns the SQL expression of a GORM default value. Values of
+// text columns are quoted unless they already are or call a function.
+func (d Dialect) literal(value, columnType string) string {
+	lower := strings.ToLower(columnType)
+	text := strings.Contains(lower, "char") || strings.Contains(lower, "text") || lower == "uuid"
+	switch {
+	case d == SQLServer && lower == "bit" && (value == "true" || value == "false"):
+		if value == "true" {
+			return "1"
+		}
+		return "0"
+	case !text, strings.HasPrefix(value, "'"), strings.Contains(value, "("):
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// isIndexed reports whether GORM options index the column or give it a
+// default, which both need a bounded text type in some dialects
+func isIndexed(options map[string]string) bool {
+	for _, option := range []string{"unique", "index", "uniqueindex", "primarykey", "default"} {
+		if _, ok := options[option]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// integerBits returns the size of an integer type, counting the extra bit
+// unsigned values need in signed columns
+func integerBits(goType string) (int, bool) {
+	switch goType {
+	case "int8":
+		return 8, true
+	case "uint8", "int16":
+		return 16, true
+	case "uint16", "int32", "sql.NullInt32":
+		return 32, true
+	case "int", "int64", "uint", "uint32", "uint64", "sql.NullInt64":
+		return 64, true
+	}
+	return 0, false
+}
@@ -0,0 +1,166 @@
+// Package migration derives versioned SQL migrations from the models of a
+// project, in the up and down file layout of golang-migrate
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+)
+
+// Migration is a pair of up and down scripts
+type Migration struct {
+	// Name describes the change, e.g. create_products
+	Name string
+	Up   string
+	Down string
+}
+
+// Create returns the migration creating the tables of the named model.
+// Models earlier in the project own tables that already exist, so foreign
+// keys to later models are left to their migrations, which add the
+// constraints skipped here. SQLite, which cannot add constraints later but
+// does not check references when creating tables, keeps them inline.
+func Create(d Dialect, project config.ProjectConfig, name string) (Migration, bool) {
+	model, ok := project.FindModel(name)
+	if !ok || model.Embedded {
+		return Migration{}, false
+	}
+	tables := Schema(d, project)
+	index := -1
+	for _, table := range tables {
+		if table.Name == model.TableName() {
+			index = table.model
+		}
+	}
+	creator := map[string]int{}
+	for _, table := range tables {
+		creator[table.Name] = table.model
+	}
+	exists := func(name string) bool {
+		i, ok := creator[name]
+		return ok && i <= index
+	}
+
+	var up, down []string
+	var created []string
+	for _, table := range tables {
+		if table.model != index {
+			continue
+		}
+		created = append(created, table.Name)
+
+		var deferred []ForeignKey
+		for _, fk := range table.ForeignKeys {
+			if !exists(fk.RefTable) && d.alterConstraints() {
+				deferred = append(deferred, fk)
+			}
+		}
+		up = append(up, d.createTable(table, deferred))
+		for _, index := range table.Indexes {
+			up = append(up, d.createIndex(table.Name, index))
+		}
+	}
+
+	// Constraints of existing tables waiting for the tables created here
+	for _, table := range tables {
+		if table.model >= index {
+			continue
+		}
+		for _, fk := range table.ForeignKeys {
+			if creator[fk.RefTable] == index && d.alterConstraints() {
+				up = append(up, d.addForeignKey(table.Name, fk))
+				down = append(down, d.dropConstraint(table.Name, fk.Name))
+			}
+		}
+	}
+
+	for i := len(created) - 1; i >= 0; i-- {
+		down = append(down, d.dropTable(created[i]))
+	}
+	return Migration{
+		Name: "create_" + model.TableName(),
+		Up:   script(up),
+		Down: script(down),
+	}, true
+}
+
+// Baseline returns the migration recording the tables of the named model
+// without changing the database, for models imported from tables that
+// already exist. It is named like the create migration, so none is written
+// later, and runs a no-op statement because some drivers reject empty
+// scripts.
+func Baseline(project config.ProjectConfig, name string) (Migration, bool) {
+	model, ok := project.FindModel(name)
+	if !ok || model.Embedded {
+		return Migration{}, false
+	}
+	note := fmt.Sprintf("-- %s existed when it was imported, nothing to change\nSELECT 1;\n", model.TableName())
+	return Migration{Name: "create_" + model.TableName(), Up: note, Down: note}, true
+}
+
+// Alter returns the migration named name turning the tables of old into
+// those of updated. Tables are compared when they exist in both, which
+// covers changes to embedded models through the tables embedding them. ok
+// is false when no table changed.
+func Alter(d Dialect, old, updated config.ProjectConfig, name string) (Migration, bool) {
+	before := Schema(d, old)
+	var up, down []string
+	for _, after := range Schema(d, updated) {
+		table, ok := findTable(before, after.Name)
+		if !ok {
+			continue
+		}
+		up = append(up, d.alterTable(table, after)...)
+		down = append(down, d.alterTable(after, table)...)
+	}
+	if len(up) == 0 {
+		return Migration{}, false
+	}
+	return Migration{Name: name, Up: script(up), Down: script(down)}, true
+}
+
+func findTable(tables []Table, name string) (Table, bool) {
+	for _, table := range tables {
+		if table.Name == name {
+			return table, true
+		}
+	}
+	return Table{}, false
+}
+
+// script joins statements into the contents of a migration file
+func script(statements []string) string {
+	if len(statements) == 0 {
+		return ""
+	}
+	return strings.Join(statements, "\n\n") + "\n"
+}
+
+func containsKey(keys []ForeignKey, key ForeignKey) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func containsCheck(checks []Check, check Check) bool {
+	for _, c := range checks {
+		if c == check {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIndex(indexes []Index, index Index) bool {
+	for _, i := range indexes {
+		if i.Name == index.Name && i.Unique == index.Unique && strings.Join(i.Columns, ",") == strings.Join(index.Columns, ",") {
+			return true
+		}
+	}
+	return false
+}
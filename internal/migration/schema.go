@@ -0,0 +1,304 @@
+package migration
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
+)
+
+// Table is the schema of a table derived from the models of a project
+type Table struct {
+	Name        string
+	Columns     []Column
+	PrimaryKey  []string
+	Indexes     []Index
+	Checks      []Check
+	ForeignKeys []ForeignKey
+
+	// model is the index of the model creating the table. Join tables are
+	// created by the later of the two models they join.
+	model int
+}
+
+// Column is a column of a table
+type Column struct {
+	Name    string
+	Type    string
+	NotNull bool
+	// Default is the SQL expression of the column default
+	Default string
+}
+
+// Index is an index over one or more columns
+type Index struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// Check is a named check constraint on a column
+type Check struct {
+	Name   string
+	Column string
+	Expr   string
+}
+
+// ForeignKey is a foreign key constraint of a column
+type ForeignKey struct {
+	Name      string
+	Column    string
+	RefTable  string
+	RefColumn string
+	OnDelete  string
+}
+
+// Schema returns the tables of the models of a project in the order the
+// models create them, each model table followed by the join tables it
+// completes
+func Schema(d Dialect, project config.ProjectConfig) []Table {
+	s := schema{dialect: d, project: project, tables: map[string]*Table{}}
+
+	var tables []*Table
+	for i, model := range project.Models {
+		if model.Embedded {
+			continue
+		}
+		table := s.table(model)
+		table.model = i
+		s.tables[model.Name] = table
+		tables = append(tables, table)
+	}
+
+	// Keys referencing other models need their key columns, so they are
+	// added once every model table is known
+	for i, model := range project.Models {
+		if model.Embedded {
+			continue
+		}
+		s.relations(i, model)
+	}
+
+	var ordered []Table
+	for _, table := range tables {
+		ordered = append(ordered, *table)
+		for _, join := range s.joins {
+			if join.model == table.model {
+				ordered = append(ordered, *join)
+			}
+		}
+	}
+	return ordered
+}
+
+// schema builds the tables of a project
+type schema struct {
+	dialect Dialect
+	project config.ProjectConfig
+	tables  map[string]*Table
+	joins   []*Table
+}
+
+// table returns the table of a model with its own columns
+func (s *schema) table(model config.ModelConfig) *Table {
+	table := &Table{Name: model.TableName()}
+	s.columns(table, model, "", 0)
+	return table
+}
+
+// columns adds the columns of the fields of a model, expanding embedded
+// models with their column prefix
+func (s *schema) columns(table *Table, model config.ModelConfig, prefix string, depth int) {
+	named := map[string]int{}
+	for _, field := range model.Fields {
+		options := gormOptions(s.project.StructTag(model, field))
+		if _, skip := options["-"]; skip {
+			continue
+		}
+
+		if embedded, ok := s.project.FindModel(field.Type); ok && embedded.Embedded {
+			if depth < 8 {
+				s.columns(table, embedded, prefix+options["embeddedprefix"], depth+1)
+			}
+			continue
+		}
+
+		name := prefix + field.ColumnName()
+		if column, ok := options["column"]; ok {
+			name = prefix + column
+		}
+		_, primaryKey := options["primarykey"]
+		key := prefix == "" && (primaryKey || model.IsKeyField(field.Name))
+		_, notNull := options["not null"]
+		autoIncrement := key && !model.IsCompositeKey() && options["autoincrement"] != "false"
+		column := Column{
+			Name:    name,
+			Type:    s.dialect.columnType(model.GoType(field), options, key, autoIncrement),
+			NotNull: notNull || key,
+		}
+		if value, ok := options["default"]; ok {
+			column.Default = s.dialect.literal(value, column.Type)
+		}
+		table.Columns = append(table.Columns, column)
+		if key {
+			table.PrimaryKey = append(table.PrimaryKey, name)
+		}
+
+		if value, ok := options["check"]; ok {
+			checkName, expr, found := strings.Cut(value, ",")
+			if !found {
+				checkName, expr = "chk_"+table.Name+"_"+name, value
+			}
+			table.Checks = append(table.Checks, Check{Name: checkName, Column: name, Expr: expr})
+		}
+		if _, ok := options["unique"]; ok {
+			table.Indexes = append(table.Indexes, Index{Name: "uni_" + table.Name + "_" + name, Columns: []string{name}, Unique: true})
+		}
+		for _, option := range []string{"index", "uniqueindex"} {
+			value, ok := options[option]
+			if !ok {
+				continue
+			}
+			indexName, _, _ := strings.Cut(value, ",")
+			if indexName == "" {
+				indexName = "idx_" + table.Name + "_" + name
+			}
+			// Fields sharing an index name form a composite index
+			if i, ok := named[indexName]; ok {
+				table.Indexes[i].Columns = append(table.Indexes[i].Columns, name)
+				continue
+			}
+			named[indexName] = len(table.Indexes)
+			table.Indexes = append(table.Indexes, Index{Name: indexName, Columns: []string{name}, Unique: option == "uniqueindex"})
+		}
+	}
+}
+
+// relations adds the foreign keys of a model: the key columns of its
+// belongs_to relations, constraints on the columns other models hold for
+// its has_one and has_many relations, and its many2many join tables
+func (s *schema) relations(index int, model config.ModelConfig) {
+	table := s.tables[model.Name]
+	for _, relation := range model.Relations {
+		target, ok := s.tables[relation.Target()]
+		if !ok {
+			continue
+		}
+		targetModel, _ := s.project.FindModel(relation.Target())
+
+		switch relation.Kind {
+		case config.RelationBelongsTo:
+			// A declared foreign key field has its column already
+			column := model.Column(relation.ForeignKey())
+			if model.GeneratesForeignKey(relation) {
+				table.Columns = append(table.Columns, Column{Name: column, Type: s.keyType(target)})
+				table.Indexes = append(table.Indexes, Index{Name: "idx_" + table.Name + "_" + column, Columns: []string{column}})
+			}
+			table.addForeignKey(column, target, relation.OnDelete)
+
+		case config.RelationHasOne, config.RelationHasMany:
+			column := targetModel.Column(model.Name + "ID")
+			if target.hasColumn(column) {
+				target.addForeignKey(column, table, "")
+			}
+
+		case config.RelationManyToMany:
+			join := &Table{Name: relation.JoinTable(model.Name), model: index}
+			if i, ok := s.modelIndex(targetModel.Name); ok && i > index {
+				join.model = i
+			}
+			ownerColumn := inflect.Snake(model.Name) + "_id"
+			targetColumn := inflect.Snake(targetModel.Name) + "_id"
+			if targetModel.Name == model.Name {
+				targetColumn = inflect.Snake(inflect.Singular(relation.Name)) + "_id"
+			}
+			join.Columns = []Column{
+				{Name: ownerColumn, Type: s.keyType(table), NotNull: true},
+				{Name: targetColumn, Type: s.keyType(target), NotNull: true},
+			}
+			join.PrimaryKey = []string{ownerColumn, targetColumn}
+			join.addForeignKey(ownerColumn, table, "CASCADE")
+			join.addForeignKey(targetColumn, target, "CASCADE")
+			s.joins = append(s.joins, join)
+		}
+	}
+}
+
+// keyType returns the type of columns referencing the single column
+// primary key of a table
+func (s *schema) keyType(table *Table) string {
+	if len(table.PrimaryKey) == 1 {
+		if column, ok := table.column(table.PrimaryKey[0]); ok {
+			return s.dialect.referenceType(column.Type)
+		}
+	}
+	return s.dialect.referenceType("")
+}
+
+func (s *schema) modelIndex(name string) (int, bool) {
+	for i, model := range s.project.Models {
+		if model.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// addForeignKey adds a constraint on column referencing the primary key of
+// target, unless the column already has one. An ON DELETE action is added
+// to an existing constraint that has none.
+func (t *Table) addForeignKey(column string, target *Table, onDelete string) {
+	if len(target.PrimaryKey) != 1 {
+		return
+	}
+	for i, fk := range t.ForeignKeys {
+		if fk.Column == column {
+			if fk.OnDelete == "" {
+				t.ForeignKeys[i].OnDelete = onDelete
+			}
+			return
+		}
+	}
+	t.ForeignKeys = append(t.ForeignKeys, ForeignKey{
+		Name:      "fk_" + t.Name + "_" + column,
+		Column:    column,
+		RefTable:  target.Name,
+		RefColumn: target.PrimaryKey[0],
+		OnDelete:  onDelete,
+	})
+}
+
+func (t *Table) column(name string) (Column, bool) {
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	return Column{}, false
+}
+
+func (t *Table) hasColumn(name string) bool {
+	_, ok := t.column(name)
+	return ok
+}
+
+// gormOptions parses the gorm key of a backquoted struct tag into its
+// options, keyed by their lower case name. Flags without a value, such as
+// unique, map to an empty string.
+func gormOptions(tag string) map[string]string {
+	options := map[string]string{}
+	value := reflect.StructTag(strings.Trim(strings.TrimSpace(tag), "`")).Get("gorm")
+	for _, option := range strings.Split(value, ";") {
+		key, value, _ := strings.Cut(option, ":")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if key == "-" || strings.HasPrefix(key, "-:") {
+			key = "-"
+		}
+		options[strings.Join(strings.Fields(key), " ")] = strings.TrimSpace(value)
+	}
+	return options
+}
@@ -0,0 +1,304 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// alterConstraints reports whether constraints can be added to and dropped
+// from existing tables
+func (d Dialect) alterConstraints() bool {
+	return d != SQLite
+}
+
+// unsupported returns a comment standing in for a change SQLite cannot
+// make to an existing table
+func (d Dialect) unsupported(format string, args ...interface{}) string {
+	return "-- SQLite cannot " + fmt.Sprintf(format, args...) + ", recreate the table to apply it"
+}
+
+// createTable returns the CREATE TABLE statement of a table without the
+// skipped foreign keys
+func (d Dialect) createTable(table Table, skip []ForeignKey) string {
+	var lines []string
+	for _, column := range table.Columns {
+		lines = append(lines, d.columnDefinition(table.Name, column))
+	}
+	if len(table.PrimaryKey) > 0 {
+		lines = append(lines, fmt.Sprintf("PRIMARY KEY (%s)", d.list(table.PrimaryKey)))
+	}
+	for _, check := range table.Checks {
+		lines = append(lines, d.checkDefinition(check))
+	}
+	for _, fk := range table.ForeignKeys {
+		if !containsKey(skip, fk) {
+			lines = append(lines, d.foreignKeyDefinition(fk))
+		}
+	}
+
+	create := "CREATE TABLE IF NOT EXISTS"
+	if d == SQLServer {
+		create = "CREATE TABLE"
+	}
+	return fmt.Sprintf("%s %s (\n    %s\n);", create, d.quote(table.Name), strings.Join(lines, ",\n    "))
+}
+
+func (d Dialect) dropTable(name string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;", d.quote(name))
+}
+
+// alterTable returns the statements turning table from into table to.
+// Constraints and indexes are dropped before and added after the columns
+// they refer to.
+func (d Dialect) alterTable(from, to Table) []string {
+	var statements []string
+
+	for _, fk := range from.ForeignKeys {
+		if !containsKey(to.ForeignKeys, fk) {
+			statements = append(statements, d.dropForeignKey(from.Name, fk))
+		}
+	}
+	for _, index := range from.Indexes {
+		if !containsIndex(to.Indexes, index) {
+			statements = append(statements, d.dropIndex(from.Name, index))
+		}
+	}
+	for _, check := range from.Checks {
+		if !containsCheck(to.Checks, check) {
+			statements = append(statements, d.dropCheck(from.Name, check))
+		}
+	}
+
+	for _, column := range from.Columns {
+		if !to.hasColumn(column.Name) {
+			statements = append(statements, d.dropColumn(from.Name, column)...)
+		}
+	}
+	var added []string
+	for _, column := range to.Columns {
+		old, ok := from.column(column.Name)
+		if !ok {
+			statements = append(statements, d.addColumn(to, column))
+			added = append(added, column.Name)
+			continue
+		}
+		statements = append(statements, d.alterColumn(to.Name, old, column)...)
+	}
+
+	// SQLite declares the constraints of added columns inline
+	inline := func(column string) bool {
+		if d.alterConstraints() {
+			return false
+		}
+		for _, name := range added {
+			if name == column {
+				return true
+			}
+		}
+		return false
+	}
+	for _, check := range to.Checks {
+		if !containsCheck(from.Checks, check) && !inline(check.Column) {
+			statements = append(statements, d.addCheck(to.Name, check))
+		}
+	}
+	for _, index := range to.Indexes {
+		if !containsIndex(from.Indexes, index) {
+			statements = append(statements, d.createIndex(to.Name, index))
+		}
+	}
+	for _, fk := range to.ForeignKeys {
+		if !containsKey(from.ForeignKeys, fk) && !inline(fk.Column) {
+			statements = append(statements, d.addForeignKey(to.Name, fk))
+		}
+	}
+	return statements
+}
+
+// addColumn returns the statement adding a column. On SQLite the column
+// carries its check and foreign key constraints.
+func (d Dialect) addColumn(table Table, column Column) string {
+	definition := d.columnDefinition(table.Name, column)
+	if d == SQLServer {
+		return fmt.Sprintf("ALTER TABLE %s ADD %s;", d.quote(table.Name), definition)
+	}
+	if !d.alterConstraints() {
+		for _, check := range table.Checks {
+			if check.Column == column.Name {
+				definition += " " + d.checkDefinition(check)
+			}
+		}
+		for _, fk := range table.ForeignKeys {
+			if fk.Column == column.Name {
+				definition += fmt.Sprintf(" REFERENCES %s (%s)", d.quote(fk.RefTable), d.quote(fk.RefColumn))
+			}
+		}
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", d.quote(table.Name), definition)
+}
+
+// dropColumn returns the statements dropping a column. SQL Server drops
+// the default constraint of the column first.
+func (d Dialect) dropColumn(table string, column Column) []string {
+	var statements []string
+	if d == SQLServer && column.Default != "" {
+		statements = append(statements, d.dropConstraint(table, defaultName(table, column.Name)))
+	}
+	drop := "DROP COLUMN"
+	if d == Postgres || d == SQLServer {
+		drop += " IF EXISTS"
+	}
+	return append(statements, fmt.Sprintf("ALTER TABLE %s %s %s;", d.quote(table), drop, d.quote(column.Name)))
+}
+
+// alterColumn returns the statements changing the type, nullability and
+// default of a column
+func (d Dialect) alterColumn(table string, from, to Column) []string {
+	if from == to {
+		return nil
+	}
+	switch d {
+	case SQLite:
+		return []string{d.unsupported("change column %s of %s", to.Name, table)}
+	case MySQL:
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", d.quote(table), d.columnDefinition(table, to))}
+	}
+
+	prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", d.quote(table), d.quote(to.Name))
+	var statements []string
+	if d == SQLServer {
+		if from.Type != to.Type || from.NotNull != to.NotNull {
+			null := " NULL"
+			if to.NotNull {
+				null = " NOT NULL"
+			}
+			statements = append(statements, prefix+" "+to.Type+null+";")
+		}
+		if from.Default != to.Default {
+			name := defaultName(table, to.Name)
+			statements = append(statements, d.dropConstraint(table, name))
+			if to.Default != "" {
+				statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s DEFAULT %s FOR %s;", d.quote(table), d.quote(name), to.Default, d.quote(to.Name)))
+			}
+		}
+		return statements
+	}
+
+	if from.Type != to.Type {
+		statements = append(statements, fmt.Sprintf("%s TYPE %s USING %s::%s;", prefix, to.Type, d.quote(to.Name), to.Type))
+	}
+	if from.NotNull != to.NotNull {
+		if to.NotNull {
+			statements = append(statements, prefix+" SET NOT NULL;")
+		} else {
+			statements = append(statements, prefix+" DROP NOT NULL;")
+		}
+	}
+	if from.Default != to.Default {
+		if to.Default != "" {
+			statements = append(statements, fmt.Sprintf("%s SET DEFAULT %s;", prefix, to.Default))
+		} else {
+			statements = append(statements, prefix+" DROP DEFAULT;")
+		}
+	}
+	return statements
+}
+
+// columnDefinition returns the definition of a column. SQL Server names
+// default constraints so they can be dropped later.
+func (d Dialect) columnDefinition(table string, column Column) string {
+	definition := d.quote(column.Name) + " " + column.Type
+	if column.NotNull {
+		definition += " NOT NULL"
+	}
+	if column.Default != "" {
+		if d == SQLServer {
+			definition += " CONSTRAINT " + d.quote(defaultName(table, column.Name))
+		}
+		definition += " DEFAULT " + column.Default
+	}
+	return definition
+}
+
+func defaultName(table, column string) string {
+	return "df_" + table + "_" + column
+}
+
+func (d Dialect) checkDefinition(check Check) string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", d.quote(check.Name), check.Expr)
+}
+
+func (d Dialect) addCheck(table string, check Check) string {
+	if !d.alterConstraints() {
+		return d.unsupported("add check constraint %s to %s", check.Name, table)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", d.quote(table), d.checkDefinition(check))
+}
+
+func (d Dialect) dropCheck(table string, check Check) string {
+	switch d {
+	case SQLite:
+		return d.unsupported("drop check constraint %s from %s", check.Name, table)
+	case MySQL:
+		return fmt.Sprintf("ALTER TABLE %s DROP CHECK %s;", d.quote(table), d.quote(check.Name))
+	}
+	return d.dropConstraint(table, check.Name)
+}
+
+func (d Dialect) foreignKeyDefinition(fk ForeignKey) string {
+	definition := fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)", d.quote(fk.Name), d.quote(fk.Column), d.quote(fk.RefTable), d.quote(fk.RefColumn))
+	if fk.OnDelete != "" {
+		definition += " ON DELETE " + fk.OnDelete
+	}
+	return definition
+}
+
+func (d Dialect) addForeignKey(table string, fk ForeignKey) string {
+	if !d.alterConstraints() {
+		return d.unsupported("add foreign key %s to %s", fk.Name, table)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", d.quote(table), d.foreignKeyDefinition(fk))
+}
+
+func (d Dialect) dropForeignKey(table string, fk ForeignKey) string {
+	switch d {
+	case SQLite:
+		return d.unsupported("drop foreign key %s from %s", fk.Name, table)
+	case MySQL:
+		return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;", d.quote(table), d.quote(fk.Name))
+	}
+	return d.dropConstraint(table, fk.Name)
+}
+
+func (d Dialect) dropConstraint(table, name string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", d.quote(table), d.quote(name))
+}
+
+func (d Dialect) createIndex(table string, index Index) string {
+	kind := "INDEX"
+	if index.Unique {
+		kind = "UNIQUE INDEX"
+	}
+	if d == Postgres || d == SQLite {
+		kind += " IF NOT EXISTS"
+	}
+	return fmt.Sprintf("CREATE %s %s ON %s (%s);", kind, d.quote(index.Name), d.quote(table), d.list(index.Columns))
+}
+
+func (d Dialect) dropIndex(table string, index Index) string {
+	switch d {
+	case MySQL:
+		return fmt.Sprintf("DROP INDEX %s ON %s;", d.quote(index.Name), d.quote(table))
+	case SQLServer:
+		return fmt.Sprintf("DROP INDEX IF EXISTS %s ON %s;", d.quote(index.Name), d.quote(table))
+	}
+	return fmt.Sprintf("DROP INDEX IF EXISTS %s;", d.quote(index.Name))
+}
+
+func (d Dialect) list(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.quote(name)
+	}
+	return strings.Join(quoted, ", ")
+}
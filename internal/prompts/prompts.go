@@ -7,15 +7,19 @@ import (
 	"strings"
 
 	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
 	"github.com/erwinhermantodev/hexa-go/internal/utils"
 )
 
+// stdin is shared by all prompts so that buffered input is not lost
+// between calls when answers are piped in
+var stdin = bufio.NewScanner(os.Stdin)
+
 // PromptForInput prompts user for input with given message
 func PromptForInput(prompt string) string {
 	fmt.Print(prompt)
-	scanner := bufio.NewScanner(os.Stdin)
-	scanner.Scan()
-	return strings.TrimSpace(scanner.Text())
+	stdin.Scan()
+	return strings.TrimSpace(stdin.Text())
 }
 
 // PromptForModels prompts user to define custom models
@@ -28,7 +32,7 @@ func PromptForModels() []config.ModelConfig {
 			break
 		}
 
-		modelName := PromptForInput("Enter model name: ")
+		modelName := inflect.Pascal(PromptForInput("Enter model name: "))
 		fields := PromptForModelFields(modelName)
 
 		hasRepo := strings.ToLower(PromptForInput("Generate repository? (y/n): ")) == "y"
@@ -54,6 +58,8 @@ func PromptForModelFields(modelName string) []config.FieldConfig {
 	fmt.Printf("Define fields for %s model:\n", modelName)
 	fmt.Println("Format: field_name field_type [gorm_tag] [json_tag] [validation]")
 	fmt.Println("Example: Name string required min=2,max=100")
+	fmt.Println("Enum:    Status enum(draft,published,archived)")
+	fmt.Println("Options: Email string unique size=120 email, Password string writeonly required")
 	fmt.Println("Press Enter on empty line to finish.")
 
 	// Add default fields
@@ -84,9 +90,25 @@ func PromptForServices() []string {
 			break
 		}
 
-		serviceName := PromptForInput("Enter service name: ")
+		serviceName := inflect.Pascal(PromptForInput("Enter service name: "))
 		services = append(services, serviceName)
 	}
 
 	return services
 }
+
+// PromptForTables prompts user to select the tables to import
+func PromptForTables(tables []string) []string {
+	fmt.Printf("Found %d tables: %s\n", len(tables), strings.Join(tables, ", "))
+	if strings.ToLower(PromptForInput("Import all tables? (y/n): ")) == "y" {
+		return tables
+	}
+
+	var selected []string
+	for _, table := range tables {
+		if strings.ToLower(PromptForInput(fmt.Sprintf("Import table %s? (y/n): ", table))) == "y" {
+			selected = append(selected, table)
+		}
+	}
+	return selected
+}
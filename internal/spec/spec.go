@@ -0,0 +1,684 @@
+package spec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Error describes a problem at a specific location in a spec file
+type Error struct {
+	File   string
+	Line   int
+	Column int
+	Path   string
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.File)
+	if e.Line > 0 {
+		fmt.Fprintf(&b, ":%d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&b, ":%d", e.Column)
+		}
+	}
+	b.WriteString(": ")
+	if e.Path != "" {
+		b.WriteString(e.Path + ": ")
+	}
+	b.WriteString(e.Msg)
+	return b.String()
+}
+
+// Errors is a list of spec errors ordered by position
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Load reads and validates a project spec from a YAML or JSON file
+func Load(path string) (config.ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	return Parse(path, data)
+}
+
+// Parse decodes and validates a project spec. JSON documents are accepted
+// as well, since JSON is a subset of YAML.
+func Parse(name string, data []byte) (config.ProjectConfig, error) {
+	var cfg config.ProjectConfig
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return cfg, decodeError(name, err)
+	}
+	if len(doc.Content) == 0 {
+		return cfg, Errors{{File: name, Msg: "spec is empty"}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return cfg, Errors{{File: name, Line: root.Line, Column: root.Column, Msg: "spec must be a mapping"}}
+	}
+
+	// Unknown keys are reported by the validator along with the other
+	// problems of the spec
+	if err := root.Decode(&cfg); err != nil {
+		return cfg, decodeError(name, err)
+	}
+
+	if err := validate(name, root, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// Validate checks a project configuration built in code, such as one
+// extended by the add commands, with the same rules as spec files. Errors
+// are reported against name without positions.
+func Validate(name string, cfg *config.ProjectConfig) error {
+	return validate(name, nil, cfg)
+}
+
+func validate(name string, root *yaml.Node, cfg *config.ProjectConfig) error {
+	v := &validator{file: name}
+	v.keys(root, "", reflect.TypeOf(*cfg))
+	v.project(root, cfg)
+	if len(v.errs) > 0 {
+		sort.SliceStable(v.errs, func(i, j int) bool { return v.errs[i].Line < v.errs[j].Line })
+		return v.errs
+	}
+
+	normalize(cfg)
+	return nil
+}
+
+// Marshal encodes a project configuration in spec format
+func Marshal(cfg config.ProjectConfig) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeError converts yaml decoding errors, which already carry
+// "line N:" prefixes, into spec errors
+func decodeError(file string, err error) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		msg := strings.TrimPrefix(err.Error(), "yaml: ")
+		line := 0
+		if n, rest, ok := splitLine(msg); ok {
+			line, msg = n, rest
+		}
+		return Errors{{File: file, Line: line, Msg: msg}}
+	}
+
+	var errs Errors
+	for _, msg := range typeErr.Errors {
+		line := 0
+		if n, rest, ok := splitLine(msg); ok {
+			line, msg = n, rest
+		}
+		errs = append(errs, &Error{File: file, Line: line, Msg: msg})
+	}
+	return errs
+}
+
+func splitLine(msg string) (int, string, bool) {
+	var line int
+	if _, err := fmt.Sscanf(msg, "line %d:", &line); err != nil {
+		return 0, msg, false
+	}
+	_, rest, _ := strings.Cut(msg, ":")
+	return line, strings.TrimSpace(rest), true
+}
+
+// normalize fills in the defaults the CLI flags would otherwise provide
+func normalize(cfg *config.ProjectConfig) {
+	if cfg.Database != "" {
+		cfg.Database = cfg.DB()
+	}
+	for i := range cfg.Models {
+		cfg.Models[i].ApplyPrimaryKey()
+		for j := range cfg.Models[i].Relations {
+			relation := &cfg.Models[i].Relations[j]
+			relation.Kind, _ = config.ParseRelationKind(relation.Kind)
+			if action, ok := config.ParseOnDelete(relation.OnDelete); ok {
+				relation.OnDelete = action
+			}
+		}
+		for j := range cfg.Models[i].Fields {
+			field := &cfg.Models[i].Fields[j]
+			tag := strings.TrimSpace(field.Tag)
+			switch {
+			case tag == "":
+				field.Tag = fmt.Sprintf("`json:\"%s\"`", field.JSONKey())
+			case !strings.HasPrefix(tag, "`"):
+				field.Tag = "`" + tag + "`"
+			}
+		}
+	}
+}
+
+type validator struct {
+	file string
+	errs Errors
+}
+
+func (v *validator) errorf(node *yaml.Node, path, format string, args ...interface{}) {
+	err := &Error{File: v.file, Path: path, Msg: fmt.Sprintf(format, args...)}
+	if node != nil {
+		err.Line, err.Column = node.Line, node.Column
+	}
+	v.errs = append(v.errs, err)
+}
+
+func (v *validator) project(root *yaml.Node, cfg *config.ProjectConfig) {
+	if strings.TrimSpace(cfg.Name) == "" {
+		v.errorf(valueOr(root, "name"), "name", "project name is required")
+	}
+	if strings.TrimSpace(cfg.ModuleName) == "" {
+		v.errorf(valueOr(root, "module"), "module", "module name is required")
+	} else if strings.ContainsAny(cfg.ModuleName, " \t") {
+		v.errorf(value(root, "module"), "module", "module name %q must not contain whitespace", cfg.ModuleName)
+	}
+	if _, ok := config.ParseDatabase(cfg.Database); !ok {
+		v.errorf(value(root, "database"), "database", "unknown database %q (use %s)", cfg.Database, strings.Join(config.Databases, ", "))
+	}
+
+	models := value(root, "models")
+	seen := map[string]bool{}
+	for i := range cfg.Models {
+		node := item(models, i)
+		path := fmt.Sprintf("models[%d]", i)
+		model := &cfg.Models[i]
+		v.model(node, path, model)
+		if seen[model.Name] {
+			v.errorf(valueOr(node, "name"), path+".name", "duplicate model %q", model.Name)
+		}
+		seen[model.Name] = true
+	}
+	for i := range cfg.Models {
+		v.relations(item(models, i), fmt.Sprintf("models[%d]", i), cfg.Models[i], cfg)
+	}
+
+	v.names(value(root, "services"), "services", "service", cfg.Services)
+	v.names(value(root, "handlers"), "handlers", "handler", cfg.Handlers)
+	v.operations(value(root, "operations"), cfg)
+}
+
+// keys reports the keys of a mapping node that t has no yaml field for,
+// descending into the mappings and sequences of the known ones
+func (v *validator) keys(node *yaml.Node, path string, t reflect.Type) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		if t.Kind() == reflect.Slice {
+			for i := 0; node != nil && node.Kind == yaml.SequenceNode && i < len(node.Content); i++ {
+				v.keys(node.Content[i], fmt.Sprintf("%s[%d]", path, i), t.Elem())
+			}
+			return
+		}
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i).Type
+		}
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		field, ok := fields[key]
+		switch {
+		case key == "<<":
+		case !ok:
+			v.errorf(node.Content[i], path, "unknown key %q", key)
+		default:
+			v.keys(node.Content[i+1], keyPath, field)
+		}
+	}
+}
+
+// names validates a list of standalone component names
+func (v *validator) names(node *yaml.Node, path, kind string, names []string) {
+	seen := map[string]bool{}
+	for i, name := range names {
+		itemNode := item(node, i)
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if !isExported(name) {
+			v.errorf(itemNode, itemPath, "%s name %q must be an exported Go identifier", kind, name)
+		} else if seen[name] {
+			v.errorf(itemNode, itemPath, "duplicate %s %q", kind, name)
+		}
+		seen[name] = true
+	}
+}
+
+func (v *validator) model(node *yaml.Node, path string, model *config.ModelConfig) {
+	if !isExported(model.Name) {
+		v.errorf(valueOr(node, "name"), path+".name", "model name %q must be an exported Go identifier", model.Name)
+	}
+
+	fields := value(node, "fields")
+	seen := map[string]bool{}
+	for i := range model.Fields {
+		fieldNode := item(fields, i)
+		fieldPath := fmt.Sprintf("%s.fields[%d]", path, i)
+		field := &model.Fields[i]
+
+		if !isExported(field.Name) {
+			v.errorf(valueOr(fieldNode, "name"), fieldPath+".name", "field name %q must be an exported Go identifier", field.Name)
+		} else if seen[field.Name] {
+			v.errorf(valueOr(fieldNode, "name"), fieldPath+".name", "duplicate field %q", field.Name)
+		}
+		seen[field.Name] = true
+
+		if _, ok := config.ParseEnumType(field.Type); ok && len(field.Enum) > 0 {
+			v.errorf(value(fieldNode, "enum"), fieldPath+".enum", "enum values are already given in the type")
+		}
+		if err := field.ParseType(); err != nil {
+			v.errorf(valueOr(fieldNode, "type"), fieldPath+".type", "%v", err)
+			continue
+		}
+		if field.Collection != "" && field.Collection != config.CollectionSlice && field.Collection != config.CollectionMap {
+			v.errorf(value(fieldNode, "collection"), fieldPath+".collection", "collection must be %q or %q", config.CollectionSlice, config.CollectionMap)
+		}
+
+		if strings.TrimSpace(field.Type) == "" {
+			v.errorf(valueOr(fieldNode, "type"), fieldPath+".type", "field type is required")
+		} else if field.IsEnum() {
+			if err := config.CheckEnum(field.Enum); err != nil {
+				v.errorf(valueOr(fieldNode, "enum"), fieldPath+".enum", "%v", err)
+			}
+		} else if len(field.Enum) > 0 {
+			v.errorf(value(fieldNode, "enum"), fieldPath+".enum", "enum values require type %q", config.FieldEnum)
+		} else if !field.IsSpecial() && !isType(field.Type) {
+			v.errorf(value(fieldNode, "type"), fieldPath+".type", "invalid Go type %q", field.Type)
+		}
+
+		if msg := checkTag(strings.Trim(strings.TrimSpace(field.Tag), "`")); msg != "" {
+			v.errorf(value(fieldNode, "tag"), fieldPath+".tag", "%s", msg)
+		}
+		if err := field.CheckOptions(); err != nil {
+			v.errorf(valueOr(fieldNode, "name"), fieldPath, "%v", err)
+		}
+		if !isJSONName(field.JSONName) {
+			v.errorf(value(fieldNode, "json_name"), fieldPath+".json_name", "invalid JSON name %q", field.JSONName)
+		}
+	}
+
+	v.primaryKey(node, path, model)
+	v.endpoints(node, path, model)
+	if model.Embedded {
+		v.embedded(node, path, model)
+	}
+}
+
+// embedded validates a model embedded into the tables of other models,
+// which has no table, key or layers of its own
+func (v *validator) embedded(node *yaml.Node, path string, model *config.ModelConfig) {
+	switch {
+	case model.HasRepo || model.HasService || model.HasHandler:
+		v.errorf(valueOr(node, "embedded"), path+".embedded", "embedded model %q cannot have a repository, service or handler", model.Name)
+	case len(model.Relations) > 0:
+		v.errorf(value(node, "relations"), path+".relations", "embedded model %q cannot have relations", model.Name)
+	case model.PrimaryKey != "":
+		v.errorf(value(node, "primary_key"), path+".primary_key", "embedded model %q cannot have a primary key", model.Name)
+	case model.Table != "":
+		v.errorf(value(node, "table"), path+".table", "embedded model %q has no table", model.Name)
+	}
+}
+
+// isJSONName reports whether s can be used as the key of a json struct tag
+func isJSONName(s string) bool {
+	return s != "-" && !strings.ContainsAny(s, "\",` \t\\")
+}
+
+// endpoints validates the route path, access and CRUD endpoints of a model
+func (v *validator) endpoints(node *yaml.Node, path string, model *config.ModelConfig) {
+	if model.Path != "" && !isRoutePath(model.Path) {
+		v.errorf(value(node, "path"), path+".path", "invalid route path %q", model.Path)
+	}
+	if model.Auth != "" && !contains(config.AuthModes, model.Auth) {
+		v.errorf(value(node, "auth"), path+".auth", "unknown auth %q (use %s)", model.Auth, strings.Join(config.AuthModes, ", "))
+	}
+
+	endpoints := value(node, "endpoints")
+	seen := map[string]bool{}
+	for i, endpoint := range model.Endpoints {
+		itemPath := fmt.Sprintf("%s.endpoints[%d]", path, i)
+		if !contains(config.Endpoints, endpoint) {
+			v.errorf(item(endpoints, i), itemPath, "unknown endpoint %q (use %s)", endpoint, strings.Join(config.Endpoints, ", "))
+		} else if seen[endpoint] {
+			v.errorf(item(endpoints, i), itemPath, "duplicate endpoint %q", endpoint)
+		}
+		seen[endpoint] = true
+	}
+}
+
+// operations validates the handler methods beyond CRUD against the
+// handlers of the project
+func (v *validator) operations(node *yaml.Node, cfg *config.ProjectConfig) {
+	methods := map[string]map[string]bool{}
+	for _, model := range cfg.Models {
+		if model.HasHandler {
+			methods[model.Name] = map[string]bool{"RegisterRoutes": true, "RegisterReadRoutes": true, "RegisterWriteRoutes": true}
+			for _, name := range model.HandlerMethods() {
+				methods[model.Name][name] = true
+			}
+		}
+	}
+	for _, handler := range cfg.Handlers {
+		if _, ok := methods[handler]; !ok {
+			methods[handler] = map[string]bool{"RegisterRoutes": true, "Health": true}
+		}
+	}
+
+	routes := map[string]bool{}
+	for i := range cfg.Operations {
+		op := &cfg.Operations[i]
+		opNode := item(node, i)
+		opPath := fmt.Sprintf("operations[%d]", i)
+
+		handler, ok := methods[op.Handler]
+		if !ok {
+			v.errorf(valueOr(opNode, "handler"), opPath+".handler", "operation %q refers to unknown handler %q", op.Name, op.Handler)
+		}
+		switch {
+		case !isExported(op.Name):
+			v.errorf(valueOr(opNode, "name"), opPath+".name", "operation name %q must be an exported Go identifier", op.Name)
+		case ok && handler[op.Name]:
+			v.errorf(valueOr(opNode, "name"), opPath+".name", "operation %q clashes with another method of handler %q", op.Name, op.Handler)
+		case ok:
+			handler[op.Name] = true
+		}
+
+		op.Method = strings.ToUpper(op.Method)
+		if !contains(config.HTTPMethods, op.Method) {
+			v.errorf(valueOr(opNode, "method"), opPath+".method", "unknown method %q (use %s)", op.Method, strings.Join(config.HTTPMethods, ", "))
+		}
+		if !strings.HasPrefix(op.Path, "/") || !isRoutePath(op.Path) {
+			v.errorf(valueOr(opNode, "path"), opPath+".path", "invalid route path %q", op.Path)
+		} else if routes[op.Method+" "+op.EchoPath()] {
+			v.errorf(valueOr(opNode, "path"), opPath+".path", "duplicate route %s %s", op.Method, op.Path)
+		}
+		routes[op.Method+" "+op.EchoPath()] = true
+	}
+}
+
+// isRoutePath reports whether s is a URL path Echo can route, with
+// optional {param} placeholders
+func isRoutePath(s string) bool {
+	return s != "" && !strings.ContainsAny(s, " \t\"`?#:*")
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryKey validates the primary key strategy of a model
+func (v *validator) primaryKey(node *yaml.Node, path string, model *config.ModelConfig) {
+	keyNode := value(node, "primary_key")
+	strategy, fields, ok := config.ParsePrimaryKey(model.PrimaryKey)
+	if !ok {
+		v.errorf(keyNode, path+".primary_key", "invalid primary key %q (use %s)", model.PrimaryKey, strings.Join(config.KeyStrategies, ", "))
+		return
+	}
+	if strategy != config.KeyComposite {
+		return
+	}
+
+	for _, name := range fields {
+		field, found := config.FieldConfig{}, false
+		for _, f := range model.Fields {
+			if f.Name == name {
+				field, found = f, true
+			}
+		}
+		if !found {
+			v.errorf(keyNode, path+".primary_key", "primary key field %q is not declared", name)
+		} else if err := config.CheckKeyField(field); err != nil {
+			v.errorf(keyNode, path+".primary_key", "%v", err)
+		}
+	}
+}
+
+// relations validates the relations of a model against the rest of the
+// project, so that the generated associations compile and resolve
+func (v *validator) relations(node *yaml.Node, path string, model config.ModelConfig, cfg *config.ProjectConfig) {
+	relations := value(node, "relations")
+	seen := map[string]bool{}
+	for _, field := range model.Fields {
+		seen[field.Name] = true
+	}
+	// generated tracks the foreign keys of belongs_to relations, which may
+	// also be declared as fields to give their columns options
+	generated := map[string]bool{}
+
+	for i, relation := range model.Relations {
+		relationNode := item(relations, i)
+		relationPath := fmt.Sprintf("%s.relations[%d]", path, i)
+
+		if !isExported(relation.Name) {
+			v.errorf(valueOr(relationNode, "name"), relationPath+".name", "relation name %q must be an exported Go identifier", relation.Name)
+			continue
+		}
+		if seen[relation.Name] {
+			v.errorf(valueOr(relationNode, "name"), relationPath+".name", "relation %q clashes with another field or relation", relation.Name)
+		}
+		seen[relation.Name] = true
+		if !isJSONName(relation.JSONName) {
+			v.errorf(value(relationNode, "json_name"), relationPath+".json_name", "invalid JSON name %q", relation.JSONName)
+		}
+
+		kind, ok := config.ParseRelationKind(relation.Kind)
+		if !ok {
+			v.errorf(valueOr(relationNode, "kind"), relationPath+".kind", "unknown relation kind %q (use %s)", relation.Kind, strings.Join(config.RelationKinds, ", "))
+			continue
+		}
+		relation.Kind = kind
+
+		if kind == config.RelationBelongsTo {
+			if generated[relation.ForeignKey()] {
+				v.errorf(valueOr(relationNode, "name"), relationPath+".name", "field %q is the foreign key of another relation", relation.ForeignKey())
+			}
+			generated[relation.ForeignKey()] = true
+			seen[relation.ForeignKey()] = true
+		}
+		if relation.OnDelete != "" {
+			if _, ok := config.ParseOnDelete(relation.OnDelete); !ok {
+				v.errorf(value(relationNode, "on_delete"), relationPath+".on_delete", "unknown on_delete %q (use %s)", relation.OnDelete, strings.Join(config.OnDeleteActions, ", "))
+			} else if kind != config.RelationBelongsTo {
+				v.errorf(value(relationNode, "on_delete"), relationPath+".on_delete", "on_delete only applies to belongs_to relations")
+			}
+		}
+
+		target, ok := cfg.FindModel(relation.Target())
+		if !ok {
+			v.errorf(valueOr(relationNode, "model"), relationPath+".model", "relation %q refers to unknown model %q", relation.Name, relation.Target())
+			continue
+		}
+		if target.Embedded {
+			v.errorf(valueOr(relationNode, "model"), relationPath+".model", "relation %q cannot refer to embedded model %q", relation.Name, target.Name)
+			continue
+		}
+		switch {
+		case (kind == config.RelationBelongsTo || kind == config.RelationManyToMany) && target.IsCompositeKey():
+			v.errorf(valueOr(relationNode, "model"), relationPath+".model", "relation %q cannot refer to model %q with a composite primary key", relation.Name, target.Name)
+			continue
+		case (kind == config.RelationHasOne || kind == config.RelationHasMany) && model.IsCompositeKey():
+			v.errorf(valueOr(relationNode, "kind"), relationPath+".kind", "%s relations are not supported on models with a composite primary key", kind)
+			continue
+		}
+		switch kind {
+		case config.RelationBelongsTo:
+			for _, field := range model.Fields {
+				if field.Name == relation.ForeignKey() && (field.Type != target.IDType() || field.Collection != "") {
+					v.errorf(valueOr(relationNode, "name"), relationPath+".name", "field %q must have type %s to be the foreign key of relation %q", field.Name, target.IDType(), relation.Name)
+				}
+			}
+		case config.RelationManyToMany:
+			if !model.HasKey() {
+				v.errorf(valueOr(relationNode, "name"), relationPath+".name", "model %q needs an ID field for relation %q", model.Name, relation.Name)
+			}
+			if !target.HasKey() {
+				v.errorf(valueOr(relationNode, "model"), relationPath+".model", "model %q needs an ID field for relation %q", target.Name, relation.Name)
+			}
+		case config.RelationHasOne, config.RelationHasMany:
+			if !model.HasKey() {
+				v.errorf(valueOr(relationNode, "name"), relationPath+".name", "model %q needs an ID field for relation %q", model.Name, relation.Name)
+			}
+			if !hasField(target, model.Name+"ID") {
+				v.errorf(valueOr(relationNode, "model"), relationPath+".model", "model %q needs a field or belongs_to relation providing %q for relation %q", target.Name, model.Name+"ID", relation.Name)
+			}
+		}
+	}
+}
+
+// hasField reports whether a model declares the field, either directly or
+// as the foreign key of a belongs_to relation
+func hasField(model config.ModelConfig, name string) bool {
+	for _, field := range model.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	for _, relation := range model.Relations {
+		if kind, _ := config.ParseRelationKind(relation.Kind); kind == config.RelationBelongsTo && relation.ForeignKey() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTag reports a malformed struct tag using the conventional
+// key:"value" syntax understood by reflect.StructTag
+func checkTag(tag string) string {
+	for tag != "" {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			break
+		}
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return fmt.Sprintf("malformed struct tag near %q", tag)
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return fmt.Sprintf("unterminated value for struct tag key %q", key)
+		}
+		tag = tag[i+1:]
+	}
+	return ""
+}
+
+// isType reports whether s parses as a Go type expression
+func isType(s string) bool {
+	expr, err := parser.ParseExpr(s)
+	return err == nil && isTypeExpr(expr)
+}
+
+func isTypeExpr(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		_, ok := t.X.(*ast.Ident)
+		return ok
+	case *ast.StarExpr:
+		return isTypeExpr(t.X)
+	case *ast.ArrayType:
+		return isTypeExpr(t.Elt)
+	case *ast.MapType:
+		return isTypeExpr(t.Key) && isTypeExpr(t.Value)
+	case *ast.IndexExpr:
+		return isTypeExpr(t.X) && isTypeExpr(t.Index)
+	case *ast.InterfaceType:
+		return true
+	}
+	return false
+}
+
+func isExported(name string) bool {
+	return token.IsIdentifier(name) && token.IsExported(name)
+}
+
+// value returns the value node for key in a mapping node
+func value(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// valueOr returns the value node for key, falling back to the mapping
+// itself so that missing keys still report a useful position
+func valueOr(node *yaml.Node, key string) *yaml.Node {
+	if v := value(node, key); v != nil {
+		return v
+	}
+	return node
+}
+
+// item returns the i-th element of a sequence node
+func item(node *yaml.Node, i int) *yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+		return nil
+	}
+	return node.Content[i]
+}
@@ -0,0 +1,70 @@
+package templates
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Ext is the file extension of template override files
+const Ext = ".tmpl"
+
+// BuiltinSource is the source reported for templates that were not
+// overridden
+const BuiltinSource = "built-in"
+
+// Names returns the sorted names of all built-in templates
+func Names() []string {
+	names := make([]string, 0, len(Registry))
+	for _, tmpl := range Registry {
+		names = append(names, tmpl.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SearchDirs returns the directories searched for template overrides, in
+// order of precedence: the project's .hexa/templates directory, then the
+// user's ~/.config/hexa-go/templates directory
+func SearchDirs(projectDir string) []string {
+	dirs := []string{filepath.Join(projectDir, ".hexa", "templates")}
+	if dir := userConfigDir(); dir != "" {
+		dirs = append(dirs, filepath.Join(dir, "hexa-go", "templates"))
+	}
+	return dirs
+}
+
+func userConfigDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config")
+}
+
+// Lookup returns the content of the named template from the first search
+// directory that overrides it, falling back to the built-in template. The
+// returned source is the override file path or BuiltinSource.
+func Lookup(name string, dirs []string) (content, source string, err error) {
+	builtin, err := Builtin(name)
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, dir := range dirs {
+		file := filepath.Join(dir, name+Ext)
+		data, err := os.ReadFile(file)
+		if err == nil {
+			return string(data), file, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", "", err
+		}
+	}
+
+	return builtin, BuiltinSource, nil
+}
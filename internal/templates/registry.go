@@ -0,0 +1,129 @@
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+)
+
+//go:embed files/*.tmpl
+var files embed.FS
+
+// Scope describes what a template is rendered for
+type Scope int
+
+const (
+	// ScopeProject templates are rendered once per project with the
+	// ProjectConfig as data
+	ScopeProject Scope = iota
+	// ScopeModel templates are rendered once per model with "Config" and
+	// "Model" as data
+	ScopeModel
+	// ScopeService templates are rendered for standalone services with
+	// "Config" and "ServiceName" as data
+	ScopeService
+	// ScopeHandler templates are rendered for standalone handlers with
+	// "Config" and "HandlerName" as data
+	ScopeHandler
+)
+
+// Template describes a built-in template and where its output goes
+type Template struct {
+	// Name is the logical name used for lookups and overrides
+	Name string
+	// File is the template file inside the embedded files directory
+	File string
+	// Scope decides how often the template is rendered and with what data
+	Scope Scope
+	// Path is the output path relative to the project root. It is itself a
+	// template rendered with the same data as the template.
+	Path string
+	// When, if set, decides whether the template is rendered at all
+	When func(project config.ProjectConfig, model config.ModelConfig) bool
+	// Managed outputs belong to the generator. They are rewritten whenever
+	// the project changes instead of following the conflict policy.
+	Managed bool
+}
+
+// Registry lists all built-in templates in generation order
+var Registry = []Template{
+	{Name: "gomod", File: "gomod.tmpl", Scope: ScopeProject, Path: "go.mod"},
+	{Name: "readme", File: "readme.tmpl", Scope: ScopeProject, Path: "README.md"},
+	{Name: "dockerfile", File: "dockerfile.tmpl", Scope: ScopeProject, Path: "Dockerfile"},
+	{Name: "docker-compose", File: "docker-compose.tmpl", Scope: ScopeProject, Path: "docker-compose.yml"},
+	{Name: "gitignore", File: "gitignore.tmpl", Scope: ScopeProject, Path: ".gitignore"},
+	{Name: "env-example", File: "env-example.tmpl", Scope: ScopeProject, Path: ".env.example"},
+	{Name: "makefile", File: "makefile.tmpl", Scope: ScopeProject, Path: "Makefile"},
+	{Name: "config", File: "config.tmpl", Scope: ScopeProject, Path: "configs/config.yaml"},
+	{Name: "locale-en", File: "locale-en.tmpl", Scope: ScopeProject, Path: "locales/en.json"},
+	{Name: "locale-id", File: "locale-id.tmpl", Scope: ScopeProject, Path: "locales/id.json"},
+	{Name: "main", File: "main.tmpl", Scope: ScopeProject, Path: "main.go"},
+	{Name: "wire", File: "wire.tmpl", Scope: ScopeProject, Path: "wire_gen.go", Managed: true},
+	{Name: "repository-interfaces", File: "repository-interfaces.tmpl", Scope: ScopeProject, Path: "repository/interfaces.go"},
+	{Name: "routes", File: "routes.tmpl", Scope: ScopeProject, Path: "transport/http/routes/routes.go"},
+	{Name: "routes-handlers", File: "routes-handlers.tmpl", Scope: ScopeProject, Path: "transport/http/routes/handlers_gen.go", Managed: true},
+	{Name: "grpc-server", File: "grpc-server.tmpl", Scope: ScopeProject, Path: "transport/grpc/server.go"},
+	{Name: "grpc-run", File: "grpc-run.tmpl", Scope: ScopeProject, Path: "transport/grpc/run.go"},
+	{Name: "utils-auth", File: "utils-auth.tmpl", Scope: ScopeProject, Path: "utils/auth.go"},
+	{Name: "utils-codes", File: "utils-codes.tmpl", Scope: ScopeProject, Path: "utils/codes.go"},
+	{Name: "utils-config", File: "utils-config.tmpl", Scope: ScopeProject, Path: "utils/config.go"},
+	{Name: "utils-jwt", File: "utils-jwt.tmpl", Scope: ScopeProject, Path: "utils/jwt.go"},
+	{Name: "utils-messages", File: "utils-messages.tmpl", Scope: ScopeProject, Path: "utils/messages.go"},
+	{Name: "utils-password", File: "utils-password.tmpl", Scope: ScopeProject, Path: "utils/password.go"},
+	{Name: "utils-validator", File: "utils-validator.tmpl", Scope: ScopeProject, Path: "utils/validator.go"},
+
+	{Name: "model", File: "model.tmpl", Scope: ScopeModel, Path: "model/{{snake .Model.Name}}.go"},
+	{Name: "repository-interface", File: "repository-interface.tmpl", Scope: ScopeModel, Path: "repository/{{snake .Model.Name}}_interface.go", When: hasRepoOrService},
+	{Name: "repository", File: "repository.tmpl", Scope: ScopeModel, Path: "repository/{{snake .Model.Name}}.go", When: hasRepo},
+	{Name: "service", File: "service.tmpl", Scope: ScopeModel, Path: "service/{{snake .Model.Name}}.go", When: hasService},
+	{Name: "handler", File: "handler.tmpl", Scope: ScopeModel, Path: "transport/http/handler/{{snake .Model.Name}}_handler.go", When: hasHandler},
+
+	{Name: "custom-service", File: "custom-service.tmpl", Scope: ScopeService, Path: "service/{{snake .ServiceName}}.go"},
+	{Name: "custom-handler", File: "custom-handler.tmpl", Scope: ScopeHandler, Path: "transport/http/handler/{{snake .HandlerName}}_handler.go"},
+}
+
+func hasRepo(_ config.ProjectConfig, model config.ModelConfig) bool    { return model.HasRepo }
+func hasService(_ config.ProjectConfig, model config.ModelConfig) bool { return model.HasService }
+func hasHandler(_ config.ProjectConfig, model config.ModelConfig) bool { return model.HasHandler }
+
+// hasRepoOrService reports whether a model needs a repository interface,
+// which its service depends on even when no implementation is generated
+func hasRepoOrService(_ config.ProjectConfig, model config.ModelConfig) bool {
+	return model.HasRepo || model.HasService
+}
+
+// ForScope returns the registered templates of the given scope
+func ForScope(scope Scope) []Template {
+	var result []Template
+	for _, tmpl := range Registry {
+		if tmpl.Scope == scope {
+			result = append(result, tmpl)
+		}
+	}
+	return result
+}
+
+// Find returns the registered template with the given name
+func Find(name string) (Template, bool) {
+	for _, tmpl := range Registry {
+		if tmpl.Name == name {
+			return tmpl, true
+		}
+	}
+	return Template{}, false
+}
+
+// Builtin returns the content of the named built-in template
+func Builtin(name string) (string, error) {
+	tmpl, ok := Find(name)
+	if !ok {
+		return "", fmt.Errorf("unknown template %q", name)
+	}
+	data, err := fs.ReadFile(files, "files/"+tmpl.File)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
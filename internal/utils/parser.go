@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/inflect"
 )
 
 // ParseFieldInput parses a field input string into FieldConfig
@@ -15,9 +16,11 @@ func ParseFieldInput(input string) config.FieldConfig {
 	}
 
 	field := config.FieldConfig{
-		Name: parts[0],
+		Name: inflect.Pascal(parts[0]),
 		Type: parts[1],
 	}
+	// Invalid types are kept as written and reported by spec validation
+	_ = field.ParseType()
 
 	// Build tag
 	var tagParts []string
@@ -25,7 +28,9 @@ func ParseFieldInput(input string) config.FieldConfig {
 
 	for i := 2; i < len(parts); i++ {
 		part := parts[i]
-		if strings.Contains(part, "=") || part == "required" || part == "email" {
+		if config.IsOption(part) {
+			_ = field.ParseOption(part)
+		} else if strings.Contains(part, "=") || part == "required" || part == "email" {
 			validate = part
 		} else {
 			tagParts = append(tagParts, part)
@@ -33,7 +38,7 @@ func ParseFieldInput(input string) config.FieldConfig {
 	}
 
 	// Generate default tags
-	jsonTag := fmt.Sprintf("json:\"%s\"", strings.ToLower(field.Name))
+	jsonTag := fmt.Sprintf("json:\"%s\"", inflect.Snake(field.Name))
 	var gormTag string
 
 	if field.Name == "ID" {
@@ -53,22 +58,31 @@ func ParseFieldInput(input string) config.FieldConfig {
 	return field
 }
 
-// ParseFieldsFromFlags parses field flags into FieldConfig slice
-func ParseFieldsFromFlags(fields []string) []config.FieldConfig {
+// ParseFieldsFromFlags parses field flags into FieldConfig slice. The
+// optional third part is either a backquoted struct tag or a comma separated
+// list of field options, the fourth the validation rules.
+func ParseFieldsFromFlags(fields []string) ([]config.FieldConfig, error) {
 	var fieldConfigs []config.FieldConfig
 
 	for _, field := range fields {
-		parts := strings.Split(field, ":")
+		parts := splitField(field)
 		if len(parts) >= 2 {
 			fieldConfig := config.FieldConfig{
-				Name: parts[0],
+				Name: inflect.Pascal(parts[0]),
 				Type: parts[1],
 			}
+			// Invalid types are kept as written and reported by spec validation
+			_ = fieldConfig.ParseType()
 
-			if len(parts) > 2 && parts[2] != "" {
+			if len(parts) > 2 && strings.HasPrefix(parts[2], "`") {
 				fieldConfig.Tag = parts[2]
 			} else {
-				jsonTag := fmt.Sprintf("json:\"%s\"", strings.ToLower(fieldConfig.Name))
+				if len(parts) > 2 {
+					if err := fieldConfig.ParseOptions(parts[2]); err != nil {
+						return nil, fmt.Errorf("field %s: %w", fieldConfig.Name, err)
+					}
+				}
+				jsonTag := fmt.Sprintf("json:\"%s\"", inflect.Snake(fieldConfig.Name))
 				fieldConfig.Tag = fmt.Sprintf("`%s`", jsonTag)
 			}
 
@@ -80,5 +94,65 @@ func ParseFieldsFromFlags(fields []string) []config.FieldConfig {
 		}
 	}
 
-	return fieldConfigs
+	return fieldConfigs, nil
+}
+
+// splitField splits a field flag on colons, keeping a backquoted struct tag,
+// which contains colons itself, in one part
+func splitField(field string) []string {
+	var parts []string
+	for field != "" {
+		if strings.HasPrefix(field, "`") {
+			if end := strings.Index(field[1:], "`"); end >= 0 {
+				parts = append(parts, field[:end+2])
+				field = strings.TrimPrefix(field[end+2:], ":")
+				continue
+			}
+		}
+		part, rest, found := strings.Cut(field, ":")
+		parts = append(parts, part)
+		if !found {
+			break
+		}
+		field = rest
+		if field == "" {
+			parts = append(parts, "")
+		}
+	}
+	return parts
+}
+
+// ParseRelationsFromFlags parses relation flags into RelationConfig slice.
+// The optional third part is the join table of a many2many relation and the
+// associated model for every other kind.
+func ParseRelationsFromFlags(relations []string) ([]config.RelationConfig, error) {
+	var relationConfigs []config.RelationConfig
+
+	for _, relation := range relations {
+		parts := strings.Split(relation, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid relation %q (format: name:kind[:model|table])", relation)
+		}
+
+		kind, ok := config.ParseRelationKind(parts[1])
+		if !ok {
+			return nil, fmt.Errorf("unknown relation kind %q in %q (use %s)", parts[1], relation, strings.Join(config.RelationKinds, ", "))
+		}
+
+		relationConfig := config.RelationConfig{
+			Name: inflect.Pascal(parts[0]),
+			Kind: kind,
+		}
+		if len(parts) > 2 && parts[2] != "" {
+			if kind == config.RelationManyToMany {
+				relationConfig.Table = parts[2]
+			} else {
+				relationConfig.Model = inflect.Pascal(parts[2])
+			}
+		}
+
+		relationConfigs = append(relationConfigs, relationConfig)
+	}
+
+	return relationConfigs, nil
 }
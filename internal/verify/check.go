@@ -0,0 +1,205 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Checker type-checks generated projects. The standard library is shared
+// between projects so it is only loaded once.
+type Checker struct {
+	fset   *token.FileSet
+	std    types.ImporterFrom
+	stubs  fs.FS
+	vendor map[string]*types.Package
+}
+
+// NewChecker creates a new Checker resolving third-party packages from
+// stubs, which holds their API laid out by import path like a module
+// cache. Only exported declarations matter; every body is a placeholder.
+func NewChecker(stubs fs.FS) *Checker {
+	fset := token.NewFileSet()
+	return &Checker{
+		fset:   fset,
+		std:    importer.ForCompiler(fset, "source", nil).(types.ImporterFrom),
+		stubs:  stubs,
+		vendor: map[string]*types.Package{},
+	}
+}
+
+// CheckProject type-checks every package of the project in dir, whose
+// module path is module, and returns all errors found
+func (c *Checker) CheckProject(dir, module string) error {
+	imp := &projectImporter{
+		checker:  c,
+		dir:      dir,
+		module:   module,
+		packages: map[string]*types.Package{},
+	}
+
+	pkgDirs, err := packageDirs(dir)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, pkgDir := range pkgDirs {
+		rel, err := filepath.Rel(dir, pkgDir)
+		if err != nil {
+			return err
+		}
+		importPath := module
+		if rel != "." {
+			importPath = path.Join(module, filepath.ToSlash(rel))
+		}
+		if _, err := imp.load(importPath, pkgDir, &errs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// packageDirs returns every directory below root containing Go files
+func packageDirs(root string) ([]string, error) {
+	seen := map[string]bool{}
+	var dirs []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && p != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "testdata") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(p, ".go") && !seen[filepath.Dir(p)] {
+			seen[filepath.Dir(p)] = true
+			dirs = append(dirs, filepath.Dir(p))
+		}
+		return nil
+	})
+	sort.Strings(dirs)
+	return dirs, err
+}
+
+// projectImporter resolves imports of a single generated project
+type projectImporter struct {
+	checker  *Checker
+	dir      string
+	module   string
+	packages map[string]*types.Package
+	errs     *[]error
+}
+
+func (i *projectImporter) Import(importPath string) (*types.Package, error) {
+	return i.ImportFrom(importPath, "", 0)
+}
+
+func (i *projectImporter) ImportFrom(importPath, srcDir string, mode types.ImportMode) (*types.Package, error) {
+	if importPath == i.module || strings.HasPrefix(importPath, i.module+"/") {
+		rel := strings.TrimPrefix(strings.TrimPrefix(importPath, i.module), "/")
+		return i.load(importPath, filepath.Join(i.dir, filepath.FromSlash(rel)), i.errs)
+	}
+	if _, err := fs.Stat(i.checker.stubs, importPath); err == nil {
+		return i.checker.loadVendor(importPath, i)
+	}
+	if isStd(importPath) {
+		return i.checker.std.ImportFrom(importPath, srcDir, mode)
+	}
+	return nil, fmt.Errorf("package %s is not available in the verification module cache", importPath)
+}
+
+// load type-checks the project package at importPath located in dir
+func (i *projectImporter) load(importPath, dir string, errs *[]error) (*types.Package, error) {
+	if pkg, ok := i.packages[importPath]; ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle through %s", importPath)
+		}
+		return pkg, nil
+	}
+	i.packages[importPath] = nil
+
+	files, err := parseDir(i.checker.fset, os.DirFS(dir), ".", dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("package %s: no Go files in %s", importPath, dir)
+	}
+
+	prev := i.errs
+	i.errs = errs
+	defer func() { i.errs = prev }()
+
+	conf := types.Config{
+		Importer: i,
+		Error: func(err error) {
+			*errs = append(*errs, err)
+		},
+	}
+	pkg, _ := conf.Check(importPath, i.checker.fset, files, nil)
+	i.packages[importPath] = pkg
+	return pkg, nil
+}
+
+// loadVendor type-checks a stub package of the module cache
+func (c *Checker) loadVendor(importPath string, imp types.ImporterFrom) (*types.Package, error) {
+	if pkg, ok := c.vendor[importPath]; ok {
+		return pkg, nil
+	}
+
+	files, err := parseDir(c.fset, c.stubs, importPath, importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := types.Config{Importer: imp}
+	pkg, err := conf.Check(importPath, c.fset, files, nil)
+	if err != nil {
+		return nil, fmt.Errorf("module cache stub %s: %w", importPath, err)
+	}
+	c.vendor[importPath] = pkg
+	return pkg, nil
+}
+
+// parseDir parses the non-test Go files of dir in fsys, naming them after
+// displayDir in positions
+func parseDir(fset *token.FileSet, fsys fs.FS, dir, displayDir string) ([]*ast.File, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(displayDir, name), src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// isStd reports whether importPath looks like a standard library package
+func isStd(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
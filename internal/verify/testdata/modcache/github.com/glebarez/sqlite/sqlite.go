@@ -0,0 +1,13 @@
+// Package sqlite is an API stub of github.com/glebarez/sqlite used to
+// type-check generated projects offline.
+package sqlite
+
+import (
+	"gorm.io/gorm"
+)
+
+type Dialector struct{}
+
+func (Dialector) Name() string { return "sqlite" }
+
+func Open(dsn string) gorm.Dialector { return Dialector{} }
@@ -0,0 +1,56 @@
+// Package validator is an API stub of github.com/go-playground/validator/v10
+// used to type-check generated projects offline.
+package validator
+
+import (
+	"reflect"
+)
+
+type FieldError interface {
+	Tag() string
+	ActualTag() string
+	Namespace() string
+	StructNamespace() string
+	Field() string
+	StructField() string
+	Value() interface{}
+	Param() string
+	Kind() reflect.Kind
+	Type() reflect.Type
+	Error() string
+}
+
+type ValidationErrors []FieldError
+
+func (ve ValidationErrors) Error() string { return "" }
+
+type InvalidValidationError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidValidationError) Error() string { return "" }
+
+type FieldLevel interface {
+	Field() reflect.Value
+	FieldName() string
+	Param() string
+}
+
+type Func func(fl FieldLevel) bool
+
+type TagNameFunc func(field reflect.StructField) string
+
+type Option func(*Validate)
+
+type Validate struct{}
+
+func New(options ...Option) *Validate { return &Validate{} }
+
+func (v *Validate) RegisterTagNameFunc(fn TagNameFunc) {}
+func (v *Validate) RegisterValidation(tag string, fn Func, callValidationEvenIfNull ...bool) error {
+	return nil
+}
+func (v *Validate) Struct(s interface{}) error              { return nil }
+func (v *Validate) Var(field interface{}, tag string) error { return nil }
+
+func WithRequiredStructEnabled() Option { return nil }
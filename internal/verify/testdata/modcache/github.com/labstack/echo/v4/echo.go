@@ -0,0 +1,131 @@
+// Package echo is an API stub of github.com/labstack/echo/v4 used to
+// type-check generated projects offline.
+package echo
+
+import (
+	"net/http"
+)
+
+// HTTP methods
+const (
+	CONNECT  = http.MethodConnect
+	DELETE   = http.MethodDelete
+	GET      = http.MethodGet
+	HEAD     = http.MethodHead
+	OPTIONS  = http.MethodOptions
+	PATCH    = http.MethodPatch
+	POST     = http.MethodPost
+	PUT      = http.MethodPut
+	TRACE    = http.MethodTrace
+	PROPFIND = "PROPFIND"
+	REPORT   = "REPORT"
+)
+
+// Headers
+const (
+	HeaderAccept        = "Accept"
+	HeaderAuthorization = "Authorization"
+	HeaderContentType   = "Content-Type"
+	HeaderOrigin        = "Origin"
+	HeaderXRequestID    = "X-Request-Id"
+)
+
+type HandlerFunc func(c Context) error
+
+type MiddlewareFunc func(next HandlerFunc) HandlerFunc
+
+type Context interface {
+	Request() *http.Request
+	Response() *Response
+	Param(name string) string
+	ParamNames() []string
+	QueryParam(name string) string
+	FormValue(name string) string
+	Get(key string) interface{}
+	Set(key string, val interface{})
+	Bind(i interface{}) error
+	Validate(i interface{}) error
+	JSON(code int, i interface{}) error
+	String(code int, s string) error
+	NoContent(code int) error
+	Redirect(code int, url string) error
+	Echo() *Echo
+}
+
+type Response struct {
+	Writer http.ResponseWriter
+	Status int
+	Size   int64
+}
+
+func (r *Response) Header() http.Header { return nil }
+
+type Route struct {
+	Method string
+	Path   string
+	Name   string
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type Echo struct {
+	Debug            bool
+	HideBanner       bool
+	HidePort         bool
+	Validator        Validator
+	HTTPErrorHandler func(err error, c Context)
+}
+
+func New() *Echo { return &Echo{} }
+
+func (e *Echo) Use(middleware ...MiddlewareFunc)                                   {}
+func (e *Echo) Pre(middleware ...MiddlewareFunc)                                   {}
+func (e *Echo) Group(prefix string, m ...MiddlewareFunc) *Group                    { return &Group{} }
+func (e *Echo) GET(path string, h HandlerFunc, m ...MiddlewareFunc) *Route         { return nil }
+func (e *Echo) POST(path string, h HandlerFunc, m ...MiddlewareFunc) *Route        { return nil }
+func (e *Echo) PUT(path string, h HandlerFunc, m ...MiddlewareFunc) *Route         { return nil }
+func (e *Echo) PATCH(path string, h HandlerFunc, m ...MiddlewareFunc) *Route       { return nil }
+func (e *Echo) DELETE(path string, h HandlerFunc, m ...MiddlewareFunc) *Route      { return nil }
+func (e *Echo) HEAD(path string, h HandlerFunc, m ...MiddlewareFunc) *Route        { return nil }
+func (e *Echo) OPTIONS(path string, h HandlerFunc, m ...MiddlewareFunc) *Route     { return nil }
+func (e *Echo) Any(path string, h HandlerFunc, m ...MiddlewareFunc) []*Route       { return nil }
+func (e *Echo) Add(method, path string, h HandlerFunc, m ...MiddlewareFunc) *Route { return nil }
+func (e *Echo) Routes() []*Route                                                   { return nil }
+func (e *Echo) Start(address string) error                                         { return nil }
+func (e *Echo) Shutdown(ctx interface{}) error                                     { return nil }
+
+type Group struct{}
+
+func (g *Group) Use(middleware ...MiddlewareFunc)                                   {}
+func (g *Group) Group(prefix string, m ...MiddlewareFunc) *Group                    { return &Group{} }
+func (g *Group) GET(path string, h HandlerFunc, m ...MiddlewareFunc) *Route         { return nil }
+func (g *Group) POST(path string, h HandlerFunc, m ...MiddlewareFunc) *Route        { return nil }
+func (g *Group) PUT(path string, h HandlerFunc, m ...MiddlewareFunc) *Route         { return nil }
+func (g *Group) PATCH(path string, h HandlerFunc, m ...MiddlewareFunc) *Route       { return nil }
+func (g *Group) DELETE(path string, h HandlerFunc, m ...MiddlewareFunc) *Route      { return nil }
+func (g *Group) HEAD(path string, h HandlerFunc, m ...MiddlewareFunc) *Route        { return nil }
+func (g *Group) OPTIONS(path string, h HandlerFunc, m ...MiddlewareFunc) *Route     { return nil }
+func (g *Group) Any(path string, h HandlerFunc, m ...MiddlewareFunc) []*Route       { return nil }
+func (g *Group) Add(method, path string, h HandlerFunc, m ...MiddlewareFunc) *Route { return nil }
+
+type HTTPError struct {
+	Code     int
+	Message  interface{}
+	Internal error
+}
+
+func NewHTTPError(code int, message ...interface{}) *HTTPError { return &HTTPError{Code: code} }
+
+func (he *HTTPError) Error() string                     { return "" }
+func (he *HTTPError) SetInternal(err error) *HTTPError  { return he }
+func (he *HTTPError) WithInternal(err error) *HTTPError { return he }
+
+var (
+	ErrBadRequest          = NewHTTPError(http.StatusBadRequest)
+	ErrUnauthorized        = NewHTTPError(http.StatusUnauthorized)
+	ErrForbidden           = NewHTTPError(http.StatusForbidden)
+	ErrNotFound            = NewHTTPError(http.StatusNotFound)
+	ErrInternalServerError = NewHTTPError(http.StatusInternalServerError)
+)
@@ -0,0 +1,33 @@
+// Package middleware is an API stub of
+// github.com/labstack/echo/v4/middleware used to type-check generated
+// projects offline.
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type Skipper func(c echo.Context) bool
+
+type CORSConfig struct {
+	Skipper          Skipper
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	AllowCredentials bool
+	ExposeHeaders    []string
+	MaxAge           int
+}
+
+var DefaultCORSConfig = CORSConfig{}
+
+func CORS() echo.MiddlewareFunc                            { return nil }
+func CORSWithConfig(config CORSConfig) echo.MiddlewareFunc { return nil }
+func Logger() echo.MiddlewareFunc                          { return nil }
+func Recover() echo.MiddlewareFunc                         { return nil }
+func RequestID() echo.MiddlewareFunc                       { return nil }
+func Secure() echo.MiddlewareFunc                          { return nil }
+func Gzip() echo.MiddlewareFunc                            { return nil }
+func BodyLimit(limit string) echo.MiddlewareFunc           { return nil }
+func RemoveTrailingSlash() echo.MiddlewareFunc             { return nil }
+func Timeout() echo.MiddlewareFunc                         { return nil }
@@ -0,0 +1,26 @@
+// Package ulid is an API stub of github.com/oklog/ulid/v2 used to
+// type-check generated projects offline.
+package ulid
+
+import (
+	"database/sql/driver"
+	"io"
+	"time"
+)
+
+type ULID [16]byte
+
+func Make() ULID                                     { return ULID{} }
+func New(ms uint64, entropy io.Reader) (ULID, error) { return ULID{}, nil }
+func MustNew(ms uint64, entropy io.Reader) ULID      { return ULID{} }
+func Parse(ulid string) (ULID, error)                { return ULID{}, nil }
+func ParseStrict(ulid string) (ULID, error)          { return ULID{}, nil }
+func MustParse(ulid string) ULID                     { return ULID{} }
+func Timestamp(t time.Time) uint64                   { return 0 }
+func (id ULID) String() string                       { return "" }
+func (id ULID) Time() uint64                         { return 0 }
+func (id ULID) Compare(other ULID) int               { return 0 }
+func (id *ULID) Scan(src interface{}) error          { return nil }
+func (id ULID) Value() (driver.Value, error)         { return nil, nil }
+func (id ULID) MarshalText() ([]byte, error)         { return nil, nil }
+func (id *ULID) UnmarshalText(v []byte) error        { return nil }
@@ -0,0 +1,36 @@
+// Package decimal is an API stub of github.com/shopspring/decimal used to
+// type-check generated projects offline.
+package decimal
+
+import (
+	"database/sql/driver"
+)
+
+type Decimal struct {
+	value *int
+	exp   int32
+}
+
+var Zero = Decimal{}
+
+func New(value int64, exp int32) Decimal                   { return Zero }
+func NewFromInt(value int64) Decimal                       { return Zero }
+func NewFromFloat(value float64) Decimal                   { return Zero }
+func NewFromString(value string) (Decimal, error)          { return Zero, nil }
+func RequireFromString(value string) Decimal               { return Zero }
+func (d Decimal) Add(d2 Decimal) Decimal                   { return d }
+func (d Decimal) Sub(d2 Decimal) Decimal                   { return d }
+func (d Decimal) Mul(d2 Decimal) Decimal                   { return d }
+func (d Decimal) Div(d2 Decimal) Decimal                   { return d }
+func (d Decimal) Cmp(d2 Decimal) int                       { return 0 }
+func (d Decimal) Equal(d2 Decimal) bool                    { return true }
+func (d Decimal) IsZero() bool                             { return true }
+func (d Decimal) IsNegative() bool                         { return false }
+func (d Decimal) Round(places int32) Decimal               { return d }
+func (d Decimal) String() string                           { return "" }
+func (d Decimal) StringFixed(places int32) string          { return "" }
+func (d Decimal) Float64() (f float64, exact bool)         { return 0, true }
+func (d Decimal) MarshalJSON() ([]byte, error)             { return nil, nil }
+func (d *Decimal) UnmarshalJSON(decimalBytes []byte) error { return nil }
+func (d Decimal) Value() (driver.Value, error)             { return d.String(), nil }
+func (d *Decimal) Scan(value interface{}) error            { return nil }
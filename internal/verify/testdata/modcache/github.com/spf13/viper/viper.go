@@ -0,0 +1,34 @@
+// Package viper is an API stub of github.com/spf13/viper used to
+// type-check generated projects offline.
+package viper
+
+import (
+	"time"
+)
+
+type ConfigFileNotFoundError struct {
+	name, locations string
+}
+
+func (fnfe ConfigFileNotFoundError) Error() string { return "" }
+
+type DecoderConfigOption func(interface{})
+
+func SetConfigName(in string)                                         {}
+func SetConfigType(in string)                                         {}
+func SetConfigFile(in string)                                         {}
+func AddConfigPath(in string)                                         {}
+func SetEnvPrefix(in string)                                          {}
+func AutomaticEnv()                                                   {}
+func BindEnv(input ...string) error                                   { return nil }
+func SetDefault(key string, value interface{})                        {}
+func Set(key string, value interface{})                               {}
+func ReadInConfig() error                                             { return nil }
+func Unmarshal(rawVal interface{}, opts ...DecoderConfigOption) error { return nil }
+func Get(key string) interface{}                                      { return nil }
+func GetString(key string) string                                     { return "" }
+func GetInt(key string) int                                           { return 0 }
+func GetBool(key string) bool                                         { return false }
+func GetDuration(key string) time.Duration                            { return 0 }
+func IsSet(key string) bool                                           { return false }
+func AllKeys() []string                                               { return nil }
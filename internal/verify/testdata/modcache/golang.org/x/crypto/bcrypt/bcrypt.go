@@ -0,0 +1,19 @@
+// Package bcrypt is an API stub of golang.org/x/crypto/bcrypt used to
+// type-check generated projects offline.
+package bcrypt
+
+import (
+	"errors"
+)
+
+const (
+	MinCost     int = 4
+	MaxCost     int = 31
+	DefaultCost int = 10
+)
+
+var ErrMismatchedHashAndPassword = errors.New("crypto/bcrypt: hashedPassword is not the hash of the given password")
+
+func GenerateFromPassword(password []byte, cost int) ([]byte, error) { return nil, nil }
+func CompareHashAndPassword(hashedPassword, password []byte) error   { return nil }
+func Cost(hashedPassword []byte) (int, error)                        { return 0, nil }
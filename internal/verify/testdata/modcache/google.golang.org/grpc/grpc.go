@@ -0,0 +1,23 @@
+// Package grpc is an API stub of google.golang.org/grpc used to type-check
+// generated projects offline.
+package grpc
+
+import (
+	"net"
+)
+
+type ServerOption interface{}
+
+type ServiceDesc struct {
+	ServiceName string
+	HandlerType interface{}
+}
+
+type Server struct{}
+
+func NewServer(opt ...ServerOption) *Server { return &Server{} }
+
+func (s *Server) RegisterService(sd *ServiceDesc, ss interface{}) {}
+func (s *Server) Serve(lis net.Listener) error                    { return nil }
+func (s *Server) Stop()                                           {}
+func (s *Server) GracefulStop()                                   {}
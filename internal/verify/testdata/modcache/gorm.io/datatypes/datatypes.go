@@ -0,0 +1,29 @@
+// Package datatypes is an API stub of gorm.io/datatypes used to type-check
+// generated projects offline.
+package datatypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+type JSON json.RawMessage
+
+func (j JSON) Value() (driver.Value, error)  { return string(j), nil }
+func (j *JSON) Scan(value interface{}) error { return nil }
+func (j JSON) MarshalJSON() ([]byte, error)  { return []byte(j), nil }
+func (j *JSON) UnmarshalJSON(b []byte) error { return nil }
+func (j JSON) String() string                { return string(j) }
+func (JSON) GormDataType() string            { return "json" }
+
+type JSONMap map[string]interface{}
+
+type JSONSlice[T any] []T
+
+type JSONType[T any] struct {
+	data T
+}
+
+func NewJSONType[T any](data T) JSONType[T] { return JSONType[T]{data: data} }
+
+func (j JSONType[T]) Data() T { return j.data }
@@ -0,0 +1,13 @@
+// Package mysql is an API stub of gorm.io/driver/mysql used to
+// type-check generated projects offline.
+package mysql
+
+import (
+	"gorm.io/gorm"
+)
+
+type Dialector struct{}
+
+func (Dialector) Name() string { return "mysql" }
+
+func Open(dsn string) gorm.Dialector { return Dialector{} }
@@ -0,0 +1,13 @@
+// Package postgres is an API stub of gorm.io/driver/postgres used to
+// type-check generated projects offline.
+package postgres
+
+import (
+	"gorm.io/gorm"
+)
+
+type Dialector struct{}
+
+func (Dialector) Name() string { return "postgres" }
+
+func Open(dsn string) gorm.Dialector { return Dialector{} }
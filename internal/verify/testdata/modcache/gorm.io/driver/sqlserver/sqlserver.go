@@ -0,0 +1,13 @@
+// Package sqlserver is an API stub of gorm.io/driver/sqlserver used to
+// type-check generated projects offline.
+package sqlserver
+
+import (
+	"gorm.io/gorm"
+)
+
+type Dialector struct{}
+
+func (Dialector) Name() string { return "sqlserver" }
+
+func Open(dsn string) gorm.Dialector { return Dialector{} }
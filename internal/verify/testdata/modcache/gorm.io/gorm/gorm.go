@@ -0,0 +1,95 @@
+// Package gorm is an API stub of gorm.io/gorm used to type-check generated
+// projects offline.
+package gorm
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrRecordNotFound = errors.New("record not found")
+	ErrDuplicatedKey  = errors.New("duplicated key not allowed")
+)
+
+type Dialector interface {
+	Name() string
+}
+
+type Option interface {
+	Apply(*Config) error
+}
+
+type Config struct {
+	SkipDefaultTransaction bool
+	PrepareStmt            bool
+	TranslateError         bool
+}
+
+func (c *Config) Apply(config *Config) error { return nil }
+
+type Model struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt DeletedAt `gorm:"index"`
+}
+
+type DeletedAt sql.NullTime
+
+type Statement struct {
+	Table string
+}
+
+type DB struct {
+	*Config
+	Error        error
+	RowsAffected int64
+	Statement    *Statement
+}
+
+func Open(dialector Dialector, opts ...Option) (*DB, error) { return &DB{}, nil }
+
+func (db *DB) DB() (*sql.DB, error)                                            { return nil, nil }
+func (db *DB) AutoMigrate(dst ...interface{}) error                            { return nil }
+func (db *DB) Create(value interface{}) *DB                                    { return db }
+func (db *DB) Save(value interface{}) *DB                                      { return db }
+func (db *DB) First(dest interface{}, conds ...interface{}) *DB                { return db }
+func (db *DB) Take(dest interface{}, conds ...interface{}) *DB                 { return db }
+func (db *DB) Last(dest interface{}, conds ...interface{}) *DB                 { return db }
+func (db *DB) Find(dest interface{}, conds ...interface{}) *DB                 { return db }
+func (db *DB) Delete(value interface{}, conds ...interface{}) *DB              { return db }
+func (db *DB) Updates(values interface{}) *DB                                  { return db }
+func (db *DB) Update(column string, value interface{}) *DB                     { return db }
+func (db *DB) Where(query interface{}, args ...interface{}) *DB                { return db }
+func (db *DB) Or(query interface{}, args ...interface{}) *DB                   { return db }
+func (db *DB) Not(query interface{}, args ...interface{}) *DB                  { return db }
+func (db *DB) Model(value interface{}) *DB                                     { return db }
+func (db *DB) Table(name string, args ...interface{}) *DB                      { return db }
+func (db *DB) Select(query interface{}, args ...interface{}) *DB               { return db }
+func (db *DB) Omit(columns ...string) *DB                                      { return db }
+func (db *DB) Preload(query string, args ...interface{}) *DB                   { return db }
+func (db *DB) Joins(query string, args ...interface{}) *DB                     { return db }
+func (db *DB) Order(value interface{}) *DB                                     { return db }
+func (db *DB) Limit(limit int) *DB                                             { return db }
+func (db *DB) Offset(offset int) *DB                                           { return db }
+func (db *DB) Count(count *int64) *DB                                          { return db }
+func (db *DB) Unscoped() *DB                                                   { return db }
+func (db *DB) Exec(sql string, values ...interface{}) *DB                      { return db }
+func (db *DB) Raw(sql string, values ...interface{}) *DB                       { return db }
+func (db *DB) Scan(dest interface{}) *DB                                       { return db }
+func (db *DB) Transaction(fc func(tx *DB) error, opts ...*sql.TxOptions) error { return nil }
+func (db *DB) Association(column string) *Association                          { return &Association{} }
+
+type Association struct {
+	DB    *DB
+	Error error
+}
+
+func (a *Association) Find(out interface{}, conds ...interface{}) error { return nil }
+func (a *Association) Append(values ...interface{}) error               { return nil }
+func (a *Association) Replace(values ...interface{}) error              { return nil }
+func (a *Association) Delete(values ...interface{}) error               { return nil }
+func (a *Association) Clear() error                                     { return nil }
+func (a *Association) Count() int64                                     { return 0 }
@@ -0,0 +1,576 @@
+// Package verify generates projects from a matrix of configurations and
+// type-checks the output, so templates that produce uncompilable Go are
+// caught before they reach users.
+//
+// Third-party packages are resolved from hand-written stubs in
+// testdata/modcache that declare the API the templates use, so checks run
+// offline. The stubs are not the real modules: they can drift from the
+// versions pinned in gomod.tmpl and accept calls the real API rejects, and
+// behavior is never exercised. TestBuild compiles a generated project
+// against the real modules when they are in the module cache. Update the
+// stubs whenever a template uses another identifier or a pinned version
+// changes.
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/generator"
+	"github.com/erwinhermantodev/hexa-go/internal/importer"
+	"github.com/erwinhermantodev/hexa-go/internal/spec"
+	"github.com/erwinhermantodev/hexa-go/internal/utils"
+)
+
+// Case is a single project configuration in the verification matrix
+type Case struct {
+	Name   string
+	Config config.ProjectConfig
+}
+
+// Matrix returns the project configurations every template change is
+// verified against
+func Matrix() ([]Case, error) {
+	product := config.ModelConfig{
+		Name: "Product",
+		Fields: append(config.DefaultModelFields(),
+			config.FieldConfig{Name: "Name", Type: "string", Tag: "`json:\"name\"`", Validate: "required"},
+			config.FieldConfig{Name: "Price", Type: "float64", Tag: "`json:\"price\"`"},
+			config.FieldConfig{Name: "Stock", Type: "int", Tag: "`json:\"stock\"`"},
+			config.FieldConfig{Name: "Status", Type: config.FieldEnum, Tag: "`json:\"status\"`", Validate: "required", Enum: []string{"draft", "published", "archived"}},
+			config.FieldConfig{Name: "Visibility", Type: config.FieldEnum, Tag: "`gorm:\"not null\" json:\"visibility\"`", Enum: []string{"public", "private"}},
+		),
+		Auth:    config.AuthProtected,
+		HasRepo: true, HasService: true, HasHandler: true,
+	}
+
+	// Users read publicly and written with a token
+	user := config.DefaultUserModel()
+	user.Auth = config.AuthMixed
+
+	// A model without time or gorm types, generated without handler
+	tag := config.ModelConfig{
+		Name: "Tag",
+		Fields: []config.FieldConfig{
+			{Name: "ID", Type: "uint", Tag: "`gorm:\"primaryKey\" json:\"id\"`"},
+			{Name: "Label", Type: "string", Tag: "`json:\"label\"`"},
+		},
+		HasRepo: true, HasService: true,
+	}
+
+	// A plain model without any layers
+	audit := config.ModelConfig{
+		Name: "AuditLog",
+		Fields: []config.FieldConfig{
+			{Name: "Message", Type: "string", Tag: "`json:\"message\"`"},
+			{Name: "LoggedAt", Type: "time.Time", Tag: "`json:\"logged_at\"`"},
+		},
+	}
+
+	typed, err := fieldTypes()
+	if err != nil {
+		return nil, fmt.Errorf("case types: %w", err)
+	}
+
+	cases := []Case{
+		{Name: "minimal", Config: project("minimal")},
+		{Name: "default", Config: project("default", config.DefaultUserModel())},
+		{Name: "models", Config: project("models", user, product, tag, audit)},
+		{Name: "repo-only", Config: project("repo-only", config.ModelConfig{
+			Name:    "Order",
+			Fields:  config.DefaultModelFields(),
+			HasRepo: true,
+		})},
+		{Name: "service-only", Config: project("service-only", config.ModelConfig{
+			Name:       "Invoice",
+			Fields:     config.DefaultModelFields(),
+			HasService: true,
+		})},
+		{Name: "relations", Config: relations()},
+		{Name: "keys", Config: keys()},
+		{Name: "types", Config: typed},
+	}
+
+	// Cases built from parsed input
+	builders := []struct {
+		name  string
+		build func() (config.ProjectConfig, error)
+	}{
+		{"options", fieldOptions},
+		{"imported", imported},
+		{"introspected", introspected},
+		{"openapi", openAPI},
+		{"sample", sample},
+	}
+	for _, b := range builders {
+		cfg, err := b.build()
+		if err != nil {
+			return nil, fmt.Errorf("case %s: %w", b.name, err)
+		}
+		cases = append(cases, Case{Name: b.name, Config: cfg})
+	}
+
+	custom := project("custom", config.DefaultUserModel(), product)
+	custom.Services = []string{"Notification"}
+	custom.Handlers = []string{"Health", "Notification"}
+
+	return append(cases,
+		Case{Name: "mysql", Config: database(config.DatabaseMySQL, typed, product)},
+		Case{Name: "sqlite", Config: database(config.DatabaseSQLite, typed, product)},
+		Case{Name: "sqlserver", Config: database(config.DatabaseSQLServer, typed, product)},
+		Case{Name: "custom", Config: custom},
+	), nil
+}
+
+// relations returns a project exercising every relation kind, including a
+// self reference, a declared foreign key field and an explicit join table
+func relations() config.ProjectConfig {
+	full := func(name string, fields []config.FieldConfig, relations ...config.RelationConfig) config.ModelConfig {
+		return config.ModelConfig{
+			Name:      name,
+			Fields:    append(config.DefaultModelFields(), fields...),
+			Relations: relations,
+			HasRepo:   true, HasService: true, HasHandler: true,
+		}
+	}
+	name := config.FieldConfig{Name: "Name", Type: "string", Tag: "`json:\"name\"`"}
+
+	return project("relations",
+		full("Customer", []config.FieldConfig{name},
+			config.RelationConfig{Name: "Orders", Kind: config.RelationHasMany},
+			config.RelationConfig{Name: "Profile", Kind: config.RelationHasOne, Model: "CustomerProfile"},
+		),
+		full("CustomerProfile", []config.FieldConfig{
+			{Name: "CustomerID", Type: "uint", Tag: "`json:\"customer_id\"`"},
+		}),
+		full("Category", []config.FieldConfig{name},
+			config.RelationConfig{Name: "Parent", Kind: config.RelationBelongsTo, Model: "Category"},
+		),
+		full("Tag", []config.FieldConfig{name}),
+		full("Order", []config.FieldConfig{
+			{Name: "CustomerID", Type: "uint", Tag: "`gorm:\"not null;index\" json:\"customer_id\"`"},
+		},
+			config.RelationConfig{Name: "Customer", Kind: config.RelationBelongsTo, OnDelete: "CASCADE"},
+			config.RelationConfig{Name: "Category", Kind: config.RelationBelongsTo},
+			config.RelationConfig{Name: "Tags", Kind: config.RelationManyToMany, Table: "order_tags"},
+		),
+	)
+}
+
+// keys returns a project with one model per primary key strategy and
+// relations referencing non-integer keys
+func keys() config.ProjectConfig {
+	keyed := func(name, primaryKey string, fields []config.FieldConfig, relations ...config.RelationConfig) config.ModelConfig {
+		model := config.ModelConfig{
+			Name:       name,
+			PrimaryKey: primaryKey,
+			Fields:     fields,
+			Relations:  relations,
+			HasRepo:    true, HasService: true, HasHandler: true,
+		}
+		model.ApplyPrimaryKey()
+		return model
+	}
+	label := config.FieldConfig{Name: "Label", Type: "string", Tag: "`json:\"label\"`"}
+
+	return project("keys",
+		keyed("Counter", config.KeyInt64, []config.FieldConfig{label}),
+		keyed("Account", config.KeyUUID, []config.FieldConfig{label}),
+		keyed("Event", config.KeyUUIDv7, []config.FieldConfig{label},
+			config.RelationConfig{Name: "Account", Kind: config.RelationBelongsTo},
+			config.RelationConfig{Name: "Tickets", Kind: config.RelationManyToMany},
+		),
+		keyed("Ticket", config.KeyULID, []config.FieldConfig{label}),
+		keyed("Membership", "composite(TenantID,Code,Seq)", []config.FieldConfig{
+			{Name: "TenantID", Type: "uint", Tag: "`json:\"tenant_id\"`"},
+			{Name: "Code", Type: "string", Tag: "`json:\"code\"`"},
+			{Name: "Seq", Type: "int32", Tag: "`json:\"seq\"`"},
+			label,
+		}),
+	)
+}
+
+// fieldTypes returns a project using every typed field form of the DSL
+func fieldTypes() (config.ProjectConfig, error) {
+	fields, err := parseFields(
+		"Nickname:*string::min=2",
+		"PublishedAt:*time.Time",
+		"Tags:[]string",
+		"Scores:map[string]int",
+		"Attributes:jsonb",
+		"Settings:json",
+		"Price:decimal(12,2)::required",
+		"Balance:money",
+		"Ratio:decimal",
+		"Level:*enum(low,high)",
+		"Ref:*uuid.UUID",
+		"Note:sql.NullString",
+	)
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+
+	return project("types", config.ModelConfig{
+		Name:    "Listing",
+		Fields:  append(config.DefaultModelFields(), fields...),
+		HasRepo: true, HasService: true, HasHandler: true,
+	}), nil
+}
+
+// fieldOptions returns a project using every field option, including a
+// renamed column in a composite key
+func fieldOptions() (config.ProjectConfig, error) {
+	memberFields, err := parseFields(
+		"Email:string:unique,size=120:required,email",
+		"Handle:string:column=user_handle,index",
+		"Role:enum(member,admin):default=admin",
+		"Secret:string:writeonly:required,min=8",
+		"Score:int:readonly,default=0",
+	)
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	member := config.ModelConfig{
+		Name:    "Member",
+		Fields:  append(config.DefaultModelFields(), memberFields...),
+		HasRepo: true, HasService: true, HasHandler: true,
+	}
+
+	seatFields, err := parseFields(
+		"Row:string:column=seat_row,size=4",
+		"Number:int",
+		"Holder:string:index",
+	)
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	seat := config.ModelConfig{
+		Name:       "Seat",
+		PrimaryKey: "composite(Row,Number)",
+		Fields:     seatFields,
+		HasRepo:    true, HasService: true, HasHandler: true,
+	}
+	seat.ApplyPrimaryKey()
+
+	return project("options", member, seat), nil
+}
+
+// importedSchema covers the DDL the SQL importer maps onto models: domains,
+// enum types, foreign keys, a join table, composite and text keys, a
+// quoted column, a custom table name and a table without primary key
+const importedSchema = `
+CREATE TYPE order_status AS ENUM ('pending', 'paid');
+CREATE DOMAIN email AS citext;
+CREATE TABLE customers (
+    id bigserial PRIMARY KEY,
+    email email NOT NULL UNIQUE,
+    name varchar(120) NOT NULL,
+    "Display Name" text DEFAULT 'a;b',
+    deleted_at timestamptz
+);
+CREATE TABLE tag (id uuid PRIMARY KEY, label text NOT NULL);
+CREATE TABLE orders (
+    id serial PRIMARY KEY,
+    customer_id bigint NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    number integer NOT NULL,
+    status order_status DEFAULT 'pending' NOT NULL,
+    total numeric(12,2),
+    meta jsonb
+);
+CREATE UNIQUE INDEX orders_customer_number ON orders(customer_id, number);
+CREATE TABLE order_tags (order_id integer REFERENCES orders, tag_id uuid REFERENCES tag, PRIMARY KEY (order_id, tag_id));
+CREATE TABLE rates (country char(2), year smallint, rate numeric(5,4) NOT NULL, PRIMARY KEY (country, year));
+CREATE TABLE settings (id text PRIMARY KEY, value bytea);
+CREATE TABLE page_views (url text, viewed_at timestamp);
+`
+
+// imported returns a project whose models are imported from SQL DDL
+func imported() (config.ProjectConfig, error) {
+	schema, err := importer.ParseSQL(importedSchema)
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	return importedProject("imported", schema)
+}
+
+// introspectedSchema is loaded into a SQLite fixture database
+const introspectedSchema = `
+CREATE TABLE customers (
+    id INTEGER PRIMARY KEY,
+    email VARCHAR(120) NOT NULL UNIQUE,
+    score REAL DEFAULT 1.5,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+CREATE TABLE tags (id INTEGER PRIMARY KEY AUTOINCREMENT, label TEXT NOT NULL);
+CREATE TABLE orders (
+    id INTEGER PRIMARY KEY,
+    customer_id INTEGER NOT NULL REFERENCES customers(id),
+    parent_id INTEGER REFERENCES orders ON DELETE SET NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    total NUMERIC(12,2) NOT NULL,
+    region CHAR(2) NOT NULL,
+    code VARCHAR(20) NOT NULL,
+    UNIQUE (region, code)
+);
+CREATE INDEX orders_status_idx ON orders(status);
+CREATE TABLE order_tags (order_id INTEGER REFERENCES orders(id), tag_id INTEGER REFERENCES tags(id), PRIMARY KEY (order_id, tag_id));
+CREATE TABLE rates (country CHAR(2), year INTEGER, rate NUMERIC(5,4) NOT NULL, PRIMARY KEY (year, country));
+CREATE TABLE logs (msg TEXT, payload BLOB);
+`
+
+// introspected returns a project whose models are introspected from a
+// SQLite fixture database
+func introspected() (config.ProjectConfig, error) {
+	dir, err := os.MkdirTemp("", "hexa-go-introspect-")
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "fixture.db")
+	db, err := sql.Open(importer.DriverSQLite, path)
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	_, err = db.Exec(introspectedSchema)
+	db.Close()
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+
+	ctx := context.Background()
+	db, err = importer.Open(ctx, importer.DriverSQLite, path)
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	defer db.Close()
+	schema, err := importer.Introspect(ctx, db, importer.DriverSQLite, "")
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	return importedProject("introspected", schema)
+}
+
+// openAPIDocument covers the schemas and paths the OpenAPI importer maps
+// onto models and handlers: validation keywords, camelCase keys, references,
+// a partial resource with a custom path, operations beyond CRUD and an
+// error schema that is not a resource
+const openAPIDocument = `
+openapi: 3.0.3
+info: {title: Shop, version: 1.0.0}
+paths:
+  /products:
+    get: {operationId: listProducts}
+    post: {operationId: createProduct}
+  /products/{productId}:
+    get: {operationId: getProduct}
+    patch: {operationId: updateProduct}
+    put: {operationId: replaceProduct, summary: Replace a product}
+    delete: {operationId: deleteProduct}
+  /products/{productId}/publish:
+    post: {operationId: publishProduct, summary: Publish a product}
+  /persons:
+    get: {operationId: listPeople}
+  /persons/{id}:
+    get:
+      operationId: getPerson
+      responses:
+        '200': {content: {application/json: {schema: {$ref: '#/components/schemas/Person'}}}}
+        '404': {$ref: '#/components/responses/NotFound'}
+        default: {content: {application/json: {schema: {$ref: '#/components/schemas/Error'}}}}
+  /reports/sales:
+    get: {operationId: salesReport, tags: [reports]}
+components:
+  responses:
+    NotFound: {content: {application/json: {schema: {$ref: '#/components/schemas/Error'}}}}
+  schemas:
+    Error:
+      type: object
+      properties:
+        code: {type: integer}
+        message: {type: string}
+    Status:
+      type: string
+      enum: [draft, published]
+    Person:
+      type: object
+      required: [email, displayName]
+      properties:
+        id: {type: string, format: uuid, readOnly: true}
+        email: {type: string, format: email, maxLength: 255}
+        displayName: {type: string, minLength: 2}
+        password: {type: string, writeOnly: true}
+        age: {type: integer, minimum: 0, maximum: 150, nullable: true}
+    Category:
+      type: object
+      properties:
+        id: {type: integer, format: int64}
+        name: {type: string}
+    Product:
+      type: object
+      required: [name, price]
+      properties:
+        id: {type: integer, readOnly: true}
+        name: {type: string, maxLength: 120}
+        price: {type: number, format: double, minimum: 0, exclusiveMinimum: true}
+        status: {$ref: '#/components/schemas/Status'}
+        ownerId: {type: integer}
+        owner: {$ref: '#/components/schemas/Person'}
+        categories: {type: array, items: {$ref: '#/components/schemas/Category'}}
+        labels: {type: array, items: {type: string}, maxItems: 10}
+        attributes: {type: object, additionalProperties: {type: string}}
+        dimensions: {type: object, properties: {width: {type: number}}}
+        publishedAt: {type: string, format: date-time, nullable: true}
+`
+
+// openAPI returns a project whose models and handlers are imported from an
+// OpenAPI document
+func openAPI() (config.ProjectConfig, error) {
+	api, err := importer.ParseOpenAPI([]byte(openAPIDocument))
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	result, err := importer.OpenAPIModels(api, importer.Options{HasRepo: true, HasService: true, HasHandler: true})
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	cfg := project("openapi", result.Models...)
+	cfg.Handlers = result.Handlers
+	cfg.Operations = result.Operations
+	if err := spec.Validate("openapi", &cfg); err != nil {
+		return config.ProjectConfig{}, err
+	}
+	return cfg, nil
+}
+
+// samplePayload covers the values models are inferred from: camelCase
+// keys, timestamps, UUIDs, nulls, scalar arrays, nested objects and arrays
+// of objects, merged across the elements of the sample
+const samplePayload = `[
+  {
+    "id": 1, "orderNo": "A-1", "total": 10.5, "paid": true, "customerId": "3f2b7c8e-4d1a-4c6b-9f0e-2a1b3c4d5e6f",
+    "placedAt": "2024-01-02T03:04:05Z", "dueDate": "2024-02-01", "tags": ["a"], "meta": {}, "note": null,
+    "customer": {"email": "a@b.co", "address": {"line1": "x", "postalCode": "1"}},
+    "items": [{"sku": "s", "qty": 1, "unitPrice": 2}]
+  },
+  {"id": 2, "orderNo": "A-2", "total": 3, "paid": false, "placedAt": null, "items": [{"sku": "t", "qty": 2, "unitPrice": 1.5}]}
+]`
+
+// sample returns a project with models inferred from a sample payload,
+// once with related nested models and once with embedded structs
+func sample() (config.ProjectConfig, error) {
+	opts := importer.Options{HasRepo: true, HasService: true, HasHandler: true}
+	related, err := importer.SampleModels("Purchase", []byte(samplePayload), opts, nil)
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	opts.Embedded = true
+	var names []string
+	for _, model := range related.Models {
+		names = append(names, model.Name)
+	}
+	embedded, err := importer.SampleModels("Receipt", []byte(samplePayload), opts, names)
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+
+	cfg := project("sample", append(related.Models, embedded.Models...)...)
+	if err := spec.Validate("sample", &cfg); err != nil {
+		return config.ProjectConfig{}, err
+	}
+	return cfg, nil
+}
+
+// database returns a project using another database than Postgres, with
+// the models of the keys and types cases whose column types differ
+func database(db string, typed config.ProjectConfig, models ...config.ModelConfig) config.ProjectConfig {
+	models = append([]config.ModelConfig{config.DefaultUserModel()}, models...)
+	models = append(models, keys().Models...)
+	models = append(models, typed.Models...)
+	cfg := project(db, models...)
+	cfg.Database = db
+	return cfg
+}
+
+// importedProject converts schema into a validated project the way the
+// import commands do
+func importedProject(name string, schema *importer.Schema) (config.ProjectConfig, error) {
+	result, err := importer.Models(schema, importer.Options{HasRepo: true, HasService: true, HasHandler: true})
+	if err != nil {
+		return config.ProjectConfig{}, err
+	}
+	cfg := project(name, result.Models...)
+	if err := spec.Validate(name, &cfg); err != nil {
+		return config.ProjectConfig{}, err
+	}
+	return cfg, nil
+}
+
+// parseFields parses field flags of the matrix
+func parseFields(flags ...string) ([]config.FieldConfig, error) {
+	return utils.ParseFieldsFromFlags(flags)
+}
+
+func project(name string, models ...config.ModelConfig) config.ProjectConfig {
+	return config.ProjectConfig{
+		Name:        name,
+		ModuleName:  "example.com/verify/" + name,
+		Description: "Verification project " + name,
+		Author:      "hexa-go",
+		Models:      models,
+	}
+}
+
+// Run generates every case of the matrix into a temporary directory and
+// type-checks the result against the third-party stubs, reporting
+// progress to out
+func Run(out io.Writer, stubs fs.FS, cases []Case) error {
+	tmpDir, err := os.MkdirTemp("", "hexa-go-verify-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	checker := NewChecker(stubs)
+	failed := 0
+	for _, c := range cases {
+		if err := runCase(checker, tmpDir, c); err != nil {
+			failed++
+			fmt.Fprintf(out, "❌ %s\n%v\n", c.Name, indent(err.Error()))
+			continue
+		}
+		fmt.Fprintf(out, "✅ %s\n", c.Name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d cases failed", failed, len(cases))
+	}
+	return nil
+}
+
+func runCase(checker *Checker, tmpDir string, c Case) error {
+	cfg := c.Config
+	cfg.Dir = filepath.Join(tmpDir, c.Name)
+
+	gen := generator.NewWithOptions(generator.Options{
+		Conflict: generator.ConflictOverwrite,
+		Out:      io.Discard,
+	})
+	if err := gen.CreateProject(cfg); err != nil {
+		return fmt.Errorf("generate: %w", err)
+	}
+
+	return checker.CheckProject(cfg.Dir, cfg.ModuleName)
+}
+
+func indent(s string) string {
+	return "   " + strings.ReplaceAll(strings.TrimRight(s, "\n"), "\n", "\n   ")
+}
@@ -0,0 +1,82 @@
+package verify
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erwinhermantodev/hexa-go/internal/config"
+	"github.com/erwinhermantodev/hexa-go/internal/generator"
+)
+
+func TestMatrix(t *testing.T) {
+	// Check the built-in templates, not overrides of the current user
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cases, err := Matrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checker := NewChecker(os.DirFS("testdata/modcache"))
+	tmpDir := t.TempDir()
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if err := runCase(checker, tmpDir, c); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestBuild compiles a generated project with the go command against the
+// real modules pinned by gomod.tmpl. The stubs of TestMatrix only cover the
+// API the templates use; this catches what they cannot, such as a signature
+// that changed upstream. Modules are only read from the local module cache,
+// which verified their checksums on download; the test is skipped when they
+// are missing.
+func TestBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a generated project")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cases, err := Matrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The sqlite case holds the models of every key strategy and field type
+	var cfg config.ProjectConfig
+	for _, c := range cases {
+		if c.Name == "sqlite" {
+			cfg = c.Config
+		}
+	}
+	cfg.Dir = filepath.Join(t.TempDir(), cfg.Name)
+
+	gen := generator.NewWithOptions(generator.Options{
+		Conflict: generator.ConflictOverwrite,
+		Out:      io.Discard,
+	})
+	if err := gen.CreateProject(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(goBin, "build", "./...")
+	cmd.Dir = cfg.Dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOSUMDB=off", "GOWORK=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil && strings.Contains(string(out), "GOPROXY=off") {
+		t.Skipf("modules are not in the module cache:\n%s", out)
+	}
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+}